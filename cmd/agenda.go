@@ -0,0 +1,47 @@
+package cmd
+
+import (
+	"fmt"
+	"os"
+
+	"github.com/kahnwong/gcal-tui/internal/calendar"
+	"github.com/spf13/cobra"
+
+	tea "charm.land/bubbletea/v2"
+)
+
+var agendaCmd = &cobra.Command{
+	Use:   "agenda",
+	Short: "Calendar agenda list view",
+	Long:  `List the events of the next days in order, grouped by day. With --print the list is written to stdout instead of opening the interactive view.`,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		days, err := cmd.Flags().GetInt("days")
+		if err != nil {
+			return err
+		}
+		if days < 1 {
+			return fmt.Errorf("invalid value %d for --days: must be at least 1", days)
+		}
+
+		if printOnly, _ := cmd.Flags().GetBool("print"); printOnly {
+			warning, err := calendar.PrintAgenda(os.Stdout, days)
+			if warning != "" {
+				fmt.Fprintln(os.Stderr, warning)
+			}
+			return err
+		}
+
+		model := calendar.NewAgendaModel(days)
+		p := tea.NewProgram(model)
+		if _, err := p.Run(); err != nil {
+			return err
+		}
+		return nil
+	},
+}
+
+func init() {
+	agendaCmd.Flags().Int("days", 7, "Number of days to list, starting today")
+	agendaCmd.Flags().Bool("print", false, "Print the agenda and exit instead of opening the interactive view")
+	rootCmd.AddCommand(agendaCmd)
+}
@@ -0,0 +1,26 @@
+package cmd
+
+import (
+	"github.com/kahnwong/gcal-tui/internal/calendar"
+	"github.com/spf13/cobra"
+
+	tea "charm.land/bubbletea/v2"
+)
+
+var monthCmd = &cobra.Command{
+	Use:   "month",
+	Short: "Calendar month view",
+	RunE: func(cmd *cobra.Command, args []string) error {
+		// Create a month view of the current month, days open in the day or week view
+		model := calendar.NewMonthModel()
+		p := tea.NewProgram(model)
+		if _, err := p.Run(); err != nil {
+			return err
+		}
+		return nil
+	},
+}
+
+func init() {
+	rootCmd.AddCommand(monthCmd)
+}
@@ -1,9 +1,15 @@
 package cmd
 
 import (
+	"fmt"
 	"log/slog"
 	"os"
+	"strings"
+	"time"
 
+	"github.com/kahnwong/gcal-tui/configs"
+	"github.com/kahnwong/gcal-tui/internal/calendar"
+	"github.com/kahnwong/gcal-tui/internal/utils"
 	"github.com/rs/zerolog"
 	slogzerolog "github.com/samber/slog-zerolog/v2"
 	"github.com/spf13/cobra"
@@ -12,6 +18,131 @@ import (
 var rootCmd = &cobra.Command{
 	Use:   "gcal-tui",
 	Short: "A terminal-based Google Calendar viewer",
+	PersistentPreRunE: func(cmd *cobra.Command, args []string) error {
+		if err := setLocation(); err != nil {
+			return err
+		}
+		if err := setRefreshInterval(); err != nil {
+			return err
+		}
+		if err := checkHours(); err != nil {
+			return err
+		}
+		if err := setSlotLength(cmd); err != nil {
+			return err
+		}
+		if err := setWeekStart(); err != nil {
+			return err
+		}
+		if err := parseFilterFlags(cmd); err != nil {
+			return err
+		}
+		// authorize before a view takes over the terminal, as it may prompt
+		calendar.Connect()
+		return nil
+	},
+}
+
+// setLocation applies the configured time zones to all date calculations.
+func setLocation() error {
+	loc, err := utils.LoadLocation(configs.AppConfig.Timezone)
+	if err != nil {
+		return fmt.Errorf("invalid timezone %q in config: %w", configs.AppConfig.Timezone, err)
+	}
+	utils.Location = loc
+
+	utils.ExtraLocations = nil
+	for _, name := range configs.AppConfig.ExtraTimezones {
+		extra, err := time.LoadLocation(name)
+		if err != nil {
+			return fmt.Errorf("invalid timezone %q in extra_timezones: %w", name, err)
+		}
+		utils.ExtraLocations = append(utils.ExtraLocations, extra)
+	}
+	return nil
+}
+
+// setRefreshInterval applies the configured auto-refresh interval to the
+// today and week views.
+func setRefreshInterval() error {
+	calendar.RefreshInterval = configs.DefaultRefreshInterval
+	if configs.AppConfig.RefreshInterval == "" {
+		return nil
+	}
+	interval, err := time.ParseDuration(configs.AppConfig.RefreshInterval)
+	if err != nil || interval < 0 {
+		return fmt.Errorf("invalid refresh_interval %q in config: must be a duration like 10m, or 0", configs.AppConfig.RefreshInterval)
+	}
+	calendar.RefreshInterval = interval
+	return nil
+}
+
+// checkHours validates the range of hours shown in the grids.
+func checkHours() error {
+	hours := configs.AppConfig.Hours.Merge(configs.DefaultHours)
+	if hours.Start < 0 || hours.End > 24 || hours.Start >= hours.End {
+		return fmt.Errorf("invalid hours %d-%d in config: start and end must be within 0-24, start before end", hours.Start, hours.End)
+	}
+	return nil
+}
+
+// setSlotLength applies the grid row length from --slot, or else the config.
+func setSlotLength(cmd *cobra.Command) error {
+	minutes := configs.AppConfig.SlotMinutes
+	if cmd.Flags().Changed("slot") {
+		var err error
+		if minutes, err = cmd.Flags().GetInt("slot"); err != nil {
+			return err
+		}
+	}
+	if minutes == 0 {
+		minutes = configs.DefaultSlotMinutes
+	}
+	if !configs.IsSlotMinutes(minutes) {
+		return fmt.Errorf("invalid slot length %d: must be 15, 30 or 60 minutes", minutes)
+	}
+	calendar.SlotLength = time.Duration(minutes) * time.Minute
+	return nil
+}
+
+// setWeekStart applies the configured first day of the week and whether week
+// views start with weekends hidden.
+func setWeekStart() error {
+	calendar.WeekStart = configs.DefaultWeekStart
+	calendar.HideWeekends = configs.AppConfig.HideWeekends
+	if configs.AppConfig.WeekStart == "" {
+		return nil
+	}
+	weekStart, ok := configs.WeekStarts[strings.ToLower(configs.AppConfig.WeekStart)]
+	if !ok {
+		return fmt.Errorf("invalid week_start %q in config: must be sunday, monday or saturday", configs.AppConfig.WeekStart)
+	}
+	calendar.WeekStart = weekStart
+	return nil
+}
+
+// parseFilterFlags copies the event filter flags that were set into
+// calendar.FilterOverride, so they win over the config.
+func parseFilterFlags(cmd *cobra.Command) error {
+	flags := map[string]*string{
+		"declined":  &calendar.FilterOverride.Declined,
+		"cancelled": &calendar.FilterOverride.Cancelled,
+		"free":      &calendar.FilterOverride.Free,
+	}
+	for name, target := range flags {
+		if !cmd.Flags().Changed(name) {
+			continue
+		}
+		mode, err := cmd.Flags().GetString(name)
+		if err != nil {
+			return err
+		}
+		if !configs.IsFilterMode(mode) {
+			return fmt.Errorf("invalid value %q for --%s: must be show, dim or hide", mode, name)
+		}
+		*target = mode
+	}
+	return nil
 }
 
 func Execute() error {
@@ -20,6 +151,10 @@ func Execute() error {
 
 func init() {
 	rootCmd.Flags().BoolP("toggle", "t", false, "Help message for toggle")
+	rootCmd.PersistentFlags().String("declined", "", "How to display declined events: show, dim or hide")
+	rootCmd.PersistentFlags().String("cancelled", "", "How to display cancelled events: show, dim or hide")
+	rootCmd.PersistentFlags().String("free", "", "How to display events marked as free: show, dim or hide")
+	rootCmd.PersistentFlags().Int("slot", configs.DefaultSlotMinutes, "Minutes per row in the today and week grids: 15, 30 or 60")
 }
 
 func init() {
@@ -1,6 +1,8 @@
 package cmd
 
 import (
+	"fmt"
+
 	"github.com/kahnwong/gcal-tui/internal/calendar"
 	"github.com/spf13/cobra"
 
@@ -10,9 +12,18 @@ import (
 var todayCmd = &cobra.Command{
 	Use:   "today",
 	Short: "Calendar today view",
+	Long:  `Show today in a time grid. With --days N, show N days starting today, moving by N days at a time.`,
 	RunE: func(cmd *cobra.Command, args []string) error {
-		// Create a today view with 1 column and 20 width
-		model := calendar.NewModel(1, 20)
+		days, err := cmd.Flags().GetInt("days")
+		if err != nil {
+			return err
+		}
+		if days < 1 {
+			return fmt.Errorf("invalid value %d for --days: must be at least 1", days)
+		}
+
+		// Create a view with a column per day sized to the terminal
+		model := calendar.NewDaysModel(days, 20)
 		p := tea.NewProgram(model)
 		if _, err := p.Run(); err != nil {
 			return err
@@ -22,5 +33,6 @@ var todayCmd = &cobra.Command{
 }
 
 func init() {
+	todayCmd.Flags().Int("days", 1, "Number of days to show, starting today")
 	rootCmd.AddCommand(todayCmd)
 }
@@ -11,8 +11,8 @@ var weekCmd = &cobra.Command{
 	Use:   "week",
 	Short: "Calendar week view",
 	RunE: func(cmd *cobra.Command, args []string) error {
-		// Create a week view with 7 columns and 20 width, starting on Monday
-		model := calendar.NewModel(7, 20)
+		// Create a week view with 7 columns sized to the terminal, starting on Monday
+		model := calendar.NewWeekModel(20)
 		p := tea.NewProgram(model)
 		if _, err := p.Run(); err != nil {
 			return err
@@ -0,0 +1,26 @@
+package cmd
+
+import (
+	"github.com/kahnwong/gcal-tui/internal/calendar"
+	"github.com/spf13/cobra"
+
+	tea "charm.land/bubbletea/v2"
+)
+
+var workweekCmd = &cobra.Command{
+	Use:   "workweek",
+	Short: "Calendar work week view, Monday to Friday",
+	RunE: func(cmd *cobra.Command, args []string) error {
+		// Create a week view with 5 columns sized to the terminal, Monday to Friday
+		model := calendar.NewWorkWeekModel(20)
+		p := tea.NewProgram(model)
+		if _, err := p.Run(); err != nil {
+			return err
+		}
+		return nil
+	},
+}
+
+func init() {
+	rootCmd.AddCommand(workweekCmd)
+}
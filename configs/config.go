@@ -6,13 +6,57 @@ import (
 	"log/slog"
 	"os"
 	"testing"
+	"time"
 
 	cliBase "github.com/kahnwong/cli-base"
 )
 
 type Calendar struct {
-	Id    string `yaml:"id"`
-	Color string `yaml:"color"`
+	Id        string      `yaml:"id"`
+	Color     string      `yaml:"color"`     // optional, overrides the calendar color set in Google
+	Preferred bool        `yaml:"preferred"` // wins when the same event is in several calendars
+	Filter    EventFilter `yaml:"filter"`
+}
+
+// Filter modes for EventFilter
+const (
+	FilterShow = "show"
+	FilterDim  = "dim"
+	FilterHide = "hide"
+)
+
+// EventFilter sets how declined, cancelled and free (transparent) events are
+// displayed: FilterShow, FilterDim or FilterHide. Empty fields inherit.
+type EventFilter struct {
+	Declined  string `yaml:"declined"`
+	Cancelled string `yaml:"cancelled"`
+	Free      string `yaml:"free"`
+}
+
+// DefaultEventFilter applies when neither flags nor config set a mode.
+var DefaultEventFilter = EventFilter{
+	Declined:  FilterDim,
+	Cancelled: FilterHide,
+	Free:      FilterShow,
+}
+
+// Merge returns f with its empty fields taken from fallback.
+func (f EventFilter) Merge(fallback EventFilter) EventFilter {
+	if f.Declined == "" {
+		f.Declined = fallback.Declined
+	}
+	if f.Cancelled == "" {
+		f.Cancelled = fallback.Cancelled
+	}
+	if f.Free == "" {
+		f.Free = fallback.Free
+	}
+	return f
+}
+
+// IsFilterMode reports whether mode is a valid EventFilter value.
+func IsFilterMode(mode string) bool {
+	return mode == FilterShow || mode == FilterDim || mode == FilterHide
 }
 
 type Account struct {
@@ -20,8 +64,73 @@ type Account struct {
 	Credentials string     `yaml:"credentials"`
 	Calendars   []Calendar `yaml:"calendars"`
 }
+
+// FetchConfig bounds how hard the Google Calendar API is hit.
+type FetchConfig struct {
+	Workers           int     `yaml:"workers"`             // concurrent requests, across accounts
+	RequestsPerSecond float64 `yaml:"requests_per_second"` // shared by all accounts
+	Burst             int     `yaml:"burst"`               // requests allowed at once before limiting
+}
+
+// DefaultFetchConfig applies to fields left unset in the config.
+var DefaultFetchConfig = FetchConfig{
+	Workers:           8,
+	RequestsPerSecond: 5,
+	Burst:             10,
+}
+
+// HoursConfig is the range of hours covered by the today and week grids,
+// scrolled through when it does not fit the terminal. End is exclusive.
+type HoursConfig struct {
+	Start int `yaml:"start"`
+	End   int `yaml:"end"`
+}
+
+// DefaultHours applies when hours are not set.
+var DefaultHours = HoursConfig{Start: 0, End: 24}
+
+// Merge returns h with an unset End taken from fallback. Start has no unset
+// value since 0 is midnight.
+func (h HoursConfig) Merge(fallback HoursConfig) HoursConfig {
+	if h.End == 0 {
+		h.End = fallback.End
+	}
+	return h
+}
+
+// DefaultSlotMinutes is the time covered by one row of the grids when
+// slot_minutes is not set.
+const DefaultSlotMinutes = 30
+
+// IsSlotMinutes reports whether minutes is a supported grid row length.
+func IsSlotMinutes(minutes int) bool {
+	return minutes == 15 || minutes == 30 || minutes == 60
+}
+
+// DefaultRefreshInterval applies when refresh_interval is not set.
+const DefaultRefreshInterval = 5 * time.Minute
+
+// WeekStarts maps the week_start values to the day weeks start on.
+var WeekStarts = map[string]time.Weekday{
+	"sunday":   time.Sunday,
+	"monday":   time.Monday,
+	"saturday": time.Saturday,
+}
+
+// DefaultWeekStart applies when week_start is not set.
+const DefaultWeekStart = time.Monday
+
 type Config struct {
-	Accounts []Account `yaml:"accounts"`
+	Accounts        []Account   `yaml:"accounts"`
+	Filter          EventFilter `yaml:"filter"`
+	Timezone        string      `yaml:"timezone"`        // IANA name, defaults to the system local zone
+	ExtraTimezones  []string    `yaml:"extra_timezones"` // shown as extra time columns in the grids
+	Fetch           FetchConfig `yaml:"fetch"`
+	RefreshInterval string      `yaml:"refresh_interval"` // e.g. "10m", "0" turns auto-refresh off
+	Hours           HoursConfig `yaml:"hours"`
+	SlotMinutes     int         `yaml:"slot_minutes"`  // 15, 30 or 60
+	WeekStart       string      `yaml:"week_start"`    // sunday, monday or saturday
+	HideWeekends    bool        `yaml:"hide_weekends"` // start week views with Monday to Friday only
 }
 
 var AppConfigBasePath string
@@ -0,0 +1,406 @@
+package calendar
+
+import (
+	"fmt"
+	"io"
+	"math"
+	"slices"
+	"strings"
+	"time"
+
+	tea "charm.land/bubbletea/v2"
+	"charm.land/lipgloss/v2"
+	"github.com/kahnwong/gcal-tui/internal/utils"
+)
+
+// Styles for the agenda
+var (
+	AgendaDayStyle   = lipgloss.NewStyle().Foreground(lipgloss.Color("#0FF")).Bold(true)
+	AgendaTodayStyle = lipgloss.NewStyle().Foreground(lipgloss.Color("#FF0000")).Bold(true)
+	AgendaTimeStyle  = lipgloss.NewStyle().Foreground(lipgloss.Color("#888")).Width(agendaTimeWidth)
+	AgendaEmptyStyle = lipgloss.NewStyle().Foreground(lipgloss.Color("#888")).Italic(true)
+)
+
+// agendaTimeWidth fits a time range like "09:00–10:30" and a space.
+const agendaTimeWidth = 13
+
+// defaultAgendaWidth is used until the terminal size is known.
+const defaultAgendaWidth = 80
+
+// AgendaModel lists the events of a range of days in order, under a header
+// for each day that has any.
+type AgendaModel struct {
+	Events    []CalendarEvent
+	StartDate time.Time // First day listed
+	Days      int       // Number of days listed, and moved by left/right
+	Width     int       // Terminal width, from tea.WindowSizeMsg
+	Height    int       // Terminal height, from tea.WindowSizeMsg
+	Status    string    // Message from the last action, shown above the footer
+	Now       time.Time // Current time, for marking today
+	Scroll    int       // First line in the viewport
+	Cursor    string    // Selected event, see eventKey
+	Detail    bool      // The detail pane for the selected event is open
+
+	loader
+}
+
+// agendaLine is one line of the agenda: a day header, an event on a day, or
+// a blank line between days.
+type agendaLine struct {
+	day   time.Time
+	event *CalendarEvent // nil for headers and blank lines
+	blank bool
+}
+
+// NewAgendaModel creates an agenda of the given number of days starting
+// today. The events are fetched once the program starts, see Init.
+func NewAgendaModel(days int) AgendaModel {
+	now := utils.Now()
+	return AgendaModel{
+		StartDate: utils.StartOfDay(now),
+		Days:      max(days, 1),
+		Now:       now,
+		loader:    newLoader(),
+	}
+}
+
+// load starts fetching the listed days in the background. Cached events for
+// them are listed right away while they are fetched again, otherwise the
+// current events stay on screen until the response arrives.
+func (m AgendaModel) load() (AgendaModel, tea.Cmd) {
+	if events, ok := m.cached(m.StartDate, m.Days); ok {
+		m.Events = events
+		m = m.scrollTo(m.Scroll)
+	}
+	cmd := m.fetch(m.StartDate, m.Days)
+	return m, cmd
+}
+
+// loaded lists a fetch result unless the user has navigated on since it was
+// requested, then prefetches the days before and after.
+func (m AgendaModel) loaded(msg eventsMsg) (AgendaModel, tea.Cmd) {
+	if !m.receive(msg) {
+		return m, nil
+	}
+	m.Events = msg.events
+	m = m.scrollTo(m.Scroll)
+	return m, m.prefetch(m.StartDate, m.Days, m.Days)
+}
+
+func (m AgendaModel) Init() tea.Cmd {
+	return tea.Batch(tickNow(), tickRefresh(), fetchCmd(m.StartDate, m.Days, m.Request), tickSpinner())
+}
+
+func (m AgendaModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
+	switch msg := msg.(type) {
+	case nowMsg:
+		m.Now = time.Time(msg).In(utils.Location)
+		return m, tickNow()
+	case tea.WindowSizeMsg:
+		m.Width, m.Height = msg.Width, msg.Height
+		m = m.scrollTo(m.Scroll)
+	case refreshMsg:
+		var cmd tea.Cmd
+		m, cmd = m.load()
+		return m, tea.Batch(cmd, tickRefresh())
+	case eventsMsg:
+		return m.loaded(msg)
+	case spinnerMsg:
+		return m, m.tick()
+	case joinMsg:
+		m.Status = msg.Status()
+	case tea.KeyPressMsg:
+		switch msg.String() {
+		case "ctrl+c", "q":
+			return m, tea.Quit
+		case "left":
+			m.StartDate = m.StartDate.AddDate(0, 0, -m.Days)
+			m.Scroll, m.Cursor, m.Detail = 0, "", false
+			return m.load()
+		case "right":
+			m.StartDate = m.StartDate.AddDate(0, 0, m.Days)
+			m.Scroll, m.Cursor, m.Detail = 0, "", false
+			return m.load()
+		case "tab":
+			m = m.moveCursor(1, true)
+		case "shift+tab":
+			m = m.moveCursor(-1, true)
+		case "enter":
+			m.Detail = !m.Detail && m.cursorEvent() != nil
+		case "esc":
+			if m.Detail {
+				m.Detail = false
+			} else {
+				m.Cursor = ""
+			}
+		case "down":
+			// arrow keys move the cursor, or else scroll
+			if m.cursorEvent() != nil {
+				m = m.moveCursor(1, false)
+				break
+			}
+			m = m.scrollTo(m.Scroll + 1)
+		case "up":
+			if m.cursorEvent() != nil {
+				m = m.moveCursor(-1, false)
+				break
+			}
+			m = m.scrollTo(m.Scroll - 1)
+		case "J":
+			// scroll by a line even while the cursor is set
+			m = m.scrollTo(m.Scroll + 1)
+		case "K":
+			m = m.scrollTo(m.Scroll - 1)
+		case "pgdown":
+			m = m.scrollTo(m.Scroll + m.viewportRows())
+		case "pgup":
+			m = m.scrollTo(m.Scroll - m.viewportRows())
+		case "r":
+			return m.load()
+		case "j":
+			cmd, status := join(m.selectedEvent())
+			m.Status = status
+			return m, cmd
+		}
+	}
+	return m, nil
+}
+
+// lines lays out the agenda: a header for every day with events, followed by
+// its events, all-day ones first.
+func (m AgendaModel) lines() []agendaLine {
+	var lines []agendaLine
+	for d := range m.Days {
+		day := m.StartDate.AddDate(0, 0, d)
+		events := eventsOn(m.Events, day)
+		if len(events) == 0 {
+			continue
+		}
+		if len(lines) > 0 {
+			lines = append(lines, agendaLine{day: day, blank: true})
+		}
+		lines = append(lines, agendaLine{day: day})
+		for i := range events {
+			lines = append(lines, agendaLine{day: day, event: &events[i]})
+		}
+	}
+	return lines
+}
+
+// cursorLine returns the first line showing the event under the cursor, or
+// -1 when it is not listed.
+func (m AgendaModel) cursorLine(lines []agendaLine) int {
+	if m.Cursor == "" {
+		return -1
+	}
+	for i, l := range lines {
+		if l.event != nil && eventKey(*l.event) == m.Cursor {
+			return i
+		}
+	}
+	return -1
+}
+
+// cursorEvent returns the event under the cursor, if it is still listed.
+func (m AgendaModel) cursorEvent() *CalendarEvent {
+	lines := m.lines()
+	if i := m.cursorLine(lines); i >= 0 {
+		return lines[i].event
+	}
+	return nil
+}
+
+// moveCursor moves the cursor by step through the listed events, each counted
+// on the first day it is listed. With wrap it goes around at either
+// end, otherwise it stops there. Without a cursor it starts at the first or
+// last event.
+func (m AgendaModel) moveCursor(step int, wrap bool) AgendaModel {
+	lines := m.lines()
+	var events []int // first line of every event
+	var keys []string
+	for i, l := range lines {
+		if l.event == nil || slices.Contains(keys, eventKey(*l.event)) {
+			continue
+		}
+		events = append(events, i)
+		keys = append(keys, eventKey(*l.event))
+	}
+	position := stepCursor(keys, m.Cursor, step, wrap)
+	if position < 0 {
+		return m
+	}
+
+	line := events[position]
+	m.Cursor = keys[position]
+	if rows := m.viewportRows(); line < m.Scroll || line >= m.Scroll+rows {
+		// keep the day header in view where possible
+		m = m.scrollTo(line - 1)
+		if line >= m.Scroll+rows {
+			m = m.scrollTo(line - rows + 1)
+		}
+	}
+	return m
+}
+
+// selectedEvent returns the event acted on by "j": the one under the cursor,
+// or else the one in progress or starting next within the listed days.
+func (m AgendaModel) selectedEvent() *CalendarEvent {
+	if e := m.cursorEvent(); e != nil {
+		return e
+	}
+	grid := Model{Events: m.Events, StartDate: m.StartDate, ColumnCount: m.Days, Now: m.Now}
+	return grid.selectedEvent()
+}
+
+// width returns the width of the agenda lines.
+func (m AgendaModel) width() int {
+	if m.Width <= 0 {
+		return defaultAgendaWidth
+	}
+	return max(m.Width-BorderStyle.GetHorizontalFrameSize(), 20)
+}
+
+// viewportRows returns how many lines of the agenda fit on screen.
+func (m AgendaModel) viewportRows() int {
+	if m.Height <= 0 {
+		return max(len(m.lines()), 1)
+	}
+	header := 1
+	return max(m.Height-BorderStyle.GetVerticalFrameSize()-header-lipgloss.Height(m.footer()), 1)
+}
+
+// scrollTo moves the viewport to start at line, keeping it within the agenda.
+func (m AgendaModel) scrollTo(line int) AgendaModel {
+	m.Scroll = max(0, min(line, len(m.lines())-m.viewportRows()))
+	return m
+}
+
+// title returns the header naming the listed range, e.g.
+// "Mon 02 Feb – Sun 08 Feb".
+func (m AgendaModel) title() string {
+	first := m.StartDate.Format("Mon 02 Jan")
+	if m.Days == 1 {
+		return first
+	}
+	return first + " – " + m.StartDate.AddDate(0, 0, m.Days-1).Format("Mon 02 Jan")
+}
+
+// renderLine draws one agenda line, width cells wide.
+func (m AgendaModel) renderLine(l agendaLine, width int) string {
+	if l.blank {
+		return ""
+	}
+	if l.event == nil {
+		label := l.day.Format("Monday 02 January")
+		if dateDiff(l.day, viewTime(m.Now, utils.Location)) == 0 {
+			return AgendaTodayStyle.Render(truncate(label+" · Today", width))
+		}
+		return AgendaDayStyle.Render(truncate(label, width))
+	}
+	line := agendaEventLine(*l.event, l.day, width)
+	if eventKey(*l.event) == m.Cursor {
+		line = lipgloss.NewStyle().Reverse(true).Render(line)
+	}
+	return line
+}
+
+// agendaTimeRange returns when an event takes place on day, e.g.
+// "09:00–10:30", "22:00–…" for events running past midnight, or "all day".
+func agendaTimeRange(e CalendarEvent, day time.Time) string {
+	if inBanner(e) {
+		return "all day"
+	}
+	start, end, _ := clipToDay(e, day)
+	from, to := start.In(utils.Location).Format("15:04"), end.In(utils.Location).Format("15:04")
+	if start.After(e.StartTime) {
+		from = "…"
+	}
+	if end.Before(e.EndTime) {
+		to = "…"
+	}
+	return from + "–" + to
+}
+
+// agendaEventLine draws an event on day: its time range, a chip in the
+// calendar color, its title and location.
+func agendaEventLine(e CalendarEvent, day time.Time, width int) string {
+	chip := lipgloss.NewStyle().Foreground(GetColorValue(e.Color)).Render("●")
+	room := max(width-agendaTimeWidth-2, 1)
+
+	title := truncate(segmentTitle(e, day), room)
+	titleStyle := lipgloss.NewStyle().Bold(true)
+	if e.Dimmed() {
+		titleStyle = lipgloss.NewStyle().Foreground(lipgloss.Color("#777")).Strikethrough(e.Declined() || e.Status == "cancelled")
+	}
+	line := AgendaTimeStyle.Render(agendaTimeRange(e, day)) + chip + " " + titleStyle.Render(title)
+
+	if left := room - len([]rune(title)) - 3; e.Location != "" && left > 0 {
+		line += SecondaryTimeLabelStyle.Render(" · " + truncate(e.Location, left))
+	}
+	return line
+}
+
+func (m AgendaModel) View() tea.View {
+	v := tea.NewView("")
+	v.AltScreen = true
+
+	width := m.width()
+	header := HeaderStyle.Width(width).Render(m.title())
+	if e := m.cursorEvent(); m.Detail && e != nil {
+		// the detail pane takes the place of the list
+		v.SetContent(BorderStyle.Render(header + "\n" + renderDetail(*e, width, utils.Location)))
+		return v
+	}
+
+	rows := []string{header}
+	lines := m.lines()
+	if len(lines) == 0 && !m.Loading {
+		rows = append(rows, AgendaEmptyStyle.Render("No events"))
+	}
+	for i := m.Scroll; i < min(m.Scroll+m.viewportRows(), len(lines)); i++ {
+		rows = append(rows, m.renderLine(lines[i], width))
+	}
+
+	v.SetContent(BorderStyle.Render(strings.Join(rows, "\n") + "\n" + m.footer()))
+	return v
+}
+
+// footer renders the key help below the agenda, preceded by status lines.
+func (m AgendaModel) footer() string {
+	period := fmt.Sprintf("%d days", m.Days)
+	if m.Days == 1 {
+		period = "day"
+	}
+	lines := []string{"←/→: Prev/Next " + period + "   ↑/↓: Scroll   J/K: Scroll line   tab: Select   enter: Details   r: Refresh   j: Join   q: Quit"}
+	if m.Status != "" {
+		lines = append([]string{m.Status}, lines...)
+	}
+	lines = append(m.statusLines(utils.Location), lines...)
+	return lipgloss.NewStyle().Width(m.width()).Render(strings.Join(lines, "\n"))
+}
+
+// PrintAgenda fetches the given number of days starting today and writes
+// them to w as a plain list, for scripts and status bars. Colors are only
+// kept when w is a terminal. Calendars that fail to load are named in the
+// returned warning, see FetchWarning.
+func PrintAgenda(w io.Writer, days int) (warning string, err error) {
+	m := NewAgendaModel(days)
+	events, fetchErr := Fetcher(m.StartDate, m.StartDate.AddDate(0, 0, m.Days))
+	if len(events) == 0 && fetchErr != nil {
+		return "", fmt.Errorf("failed to fetch events: %w", fetchErr)
+	}
+	m.Events = events
+
+	lines := m.lines()
+	if len(lines) == 0 {
+		_, err = lipgloss.Fprintln(w, "No events")
+		return FetchWarning(fetchErr), err
+	}
+	for _, l := range lines {
+		// no terminal to fit, so titles are never cut short
+		if _, err = lipgloss.Fprintln(w, m.renderLine(l, math.MaxInt32)); err != nil {
+			return "", err
+		}
+	}
+	return FetchWarning(fetchErr), nil
+}
@@ -0,0 +1,175 @@
+package calendar
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+	"time"
+
+	tea "charm.land/bubbletea/v2"
+	"github.com/kahnwong/gcal-tui/internal/utils"
+)
+
+func agendaEvents(monday time.Time) []CalendarEvent {
+	at := func(day, hour int) time.Time { return monday.AddDate(0, 0, day).Add(time.Duration(hour) * time.Hour) }
+	return []CalendarEvent{
+		{ID: "review", Title: "Review", StartTime: at(0, 14), EndTime: at(0, 15), Location: "Room 4"},
+		{ID: "standup", Title: "Standup", StartTime: at(0, 9), EndTime: at(0, 10)},
+		{ID: "trip", Title: "Trip", StartTime: at(0, 0), EndTime: at(2, 0), AllDay: true},
+		{ID: "late", Title: "Release", StartTime: at(2, 22), EndTime: at(3, 1)},
+	}
+}
+
+func TestAgendaLines(t *testing.T) {
+	original := utils.Location
+	utils.Location = time.UTC
+	defer func() { utils.Location = original }()
+
+	monday := time.Date(2026, 2, 2, 0, 0, 0, 0, time.UTC)
+	m := AgendaModel{StartDate: monday, Days: 7, Now: monday, Events: agendaEvents(monday)}
+
+	var got []string
+	for _, l := range m.lines() {
+		switch {
+		case l.blank:
+			got = append(got, "")
+		case l.event == nil:
+			got = append(got, l.day.Format("Mon"))
+		default:
+			got = append(got, agendaTimeRange(*l.event, l.day)+" "+l.event.Title)
+		}
+	}
+	want := []string{
+		"Mon", "all day Trip", "09:00–10:00 Standup", "14:00–15:00 Review", "",
+		"Tue", "all day Trip", "",
+		"Wed", "22:00–… Release", "",
+		"Thu", "…–01:00 Release",
+	}
+	if strings.Join(got, "\n") != strings.Join(want, "\n") {
+		t.Errorf("lines() =\n%s\nwant\n%s", strings.Join(got, "\n"), strings.Join(want, "\n"))
+	}
+
+	if line := m.renderLine(m.lines()[3], 80); !strings.Contains(line, "Review") || !strings.Contains(line, "Room 4") {
+		t.Errorf("Expected the event line to show the title and location, got %q", line)
+	}
+	if line := m.renderLine(m.lines()[0], 80); !strings.Contains(line, "Monday 02 February · Today") {
+		t.Errorf("Expected today's header to be marked, got %q", line)
+	}
+}
+
+func TestAgendaCursor(t *testing.T) {
+	monday := time.Date(2026, 2, 2, 0, 0, 0, 0, time.UTC)
+	m := AgendaModel{StartDate: monday, Days: 7, Now: monday, Events: agendaEvents(monday)}
+
+	steps := []struct {
+		key  tea.KeyPressMsg
+		want string
+	}{
+		{tea.KeyPressMsg{Code: tea.KeyTab}, "Trip"},
+		{tea.KeyPressMsg{Code: tea.KeyDown}, "Standup"},
+		{tea.KeyPressMsg{Code: tea.KeyDown}, "Review"},
+		{tea.KeyPressMsg{Code: tea.KeyDown}, "Release"}, // Trip's second day is skipped
+		{tea.KeyPressMsg{Code: tea.KeyDown}, "Release"},
+		{tea.KeyPressMsg{Code: tea.KeyTab}, "Trip"},
+		{tea.KeyPressMsg{Code: tea.KeyTab, Mod: tea.ModShift}, "Release"},
+	}
+	for _, step := range steps {
+		updated, _ := m.Update(step.key)
+		m = updated.(AgendaModel)
+		if e := m.cursorEvent(); e == nil || e.Title != step.want {
+			t.Fatalf("After %s: cursor on %+v, want %s", step.key, e, step.want)
+		}
+	}
+
+	updated, _ := m.Update(tea.KeyPressMsg{Code: tea.KeyEnter})
+	m = updated.(AgendaModel)
+	if !m.Detail || !strings.Contains(m.View().Content, "enter/esc: Close") {
+		t.Error("Expected enter to open the detail pane")
+	}
+
+	updated, cmd := m.Update(tea.KeyPressMsg{Code: tea.KeyRight})
+	m = updated.(AgendaModel)
+	if !m.StartDate.Equal(monday.AddDate(0, 0, 7)) || m.Cursor != "" || m.Detail || cmd == nil {
+		t.Errorf("Expected right to load the next 7 days and reset the cursor, got %v", m.StartDate)
+	}
+}
+
+func TestAgendaScroll(t *testing.T) {
+	monday := time.Date(2026, 2, 2, 0, 0, 0, 0, time.UTC)
+	m := AgendaModel{StartDate: monday, Days: 7, Now: monday, Events: agendaEvents(monday)}
+	updated, _ := m.Update(tea.WindowSizeMsg{Width: 80, Height: 10})
+	m = updated.(AgendaModel)
+
+	if lines := strings.Count(m.View().Content, "\n") + 1; lines > 10 {
+		t.Errorf("Expected the view to fit in 10 lines, got %d", lines)
+	}
+	rows := m.viewportRows()
+	updated, _ = m.Update(tea.KeyPressMsg{Code: tea.KeyPgDown})
+	m = updated.(AgendaModel)
+	if want := min(rows, len(m.lines())-rows); m.Scroll != want {
+		t.Errorf("Scroll = %d, want %d", m.Scroll, want)
+	}
+
+	// moving the cursor to the top brings it back into view
+	updated, _ = m.Update(tea.KeyPressMsg{Code: tea.KeyTab})
+	m = updated.(AgendaModel)
+	if m.Scroll != 0 {
+		t.Errorf("Expected the cursor to be scrolled into view, got Scroll = %d", m.Scroll)
+	}
+
+	// J scrolls while the cursor is set, without moving it
+	cursor := m.Cursor
+	updated, _ = m.Update(tea.KeyPressMsg{Code: 'J', Text: "J"})
+	m = updated.(AgendaModel)
+	if m.Scroll != 1 || m.Cursor != cursor {
+		t.Errorf("After J: Scroll = %d, Cursor = %q, want 1 and %q", m.Scroll, m.Cursor, cursor)
+	}
+}
+
+func TestAgendaPrefetch(t *testing.T) {
+	original := Fetcher
+	defer func() { Fetcher = original }()
+	Fetcher = func(start, _ time.Time) ([]CalendarEvent, error) {
+		return []CalendarEvent{{Title: start.Format("Jan 2"), StartTime: start.Add(9 * time.Hour), EndTime: start.Add(10 * time.Hour)}}, nil
+	}
+
+	monday := time.Date(2026, 2, 2, 0, 0, 0, 0, time.UTC)
+	m := AgendaModel{StartDate: monday, Days: 3, Now: monday, loader: newLoader()}
+
+	updated, cmd := m.Update(fetchCmd(monday, m.Days, 1)())
+	m = updated.(AgendaModel)
+	if cmd == nil {
+		t.Fatal("Expected the days before and after to be prefetched")
+	}
+	updated, _ = m.Update(fetchCmd(monday.AddDate(0, 0, 3), m.Days, 0)())
+	m = updated.(AgendaModel)
+	if m.Events[0].Title != "Feb 2" {
+		t.Errorf("Expected a prefetch not to change the listed events, got %+v", m.Events)
+	}
+
+	updated, cmd = m.Update(tea.KeyPressMsg{Code: tea.KeyRight})
+	m = updated.(AgendaModel)
+	if len(m.Events) != 1 || m.Events[0].Title != "Feb 5" {
+		t.Errorf("Expected the cached events for Feb 5 right away, got %+v", m.Events)
+	}
+	if !m.Loading || cmd == nil {
+		t.Error("Expected the cached days to be fetched again")
+	}
+}
+
+func TestPrintAgenda(t *testing.T) {
+	original := Fetcher
+	defer func() { Fetcher = original }()
+	Fetcher = func(start, end time.Time) ([]CalendarEvent, error) {
+		return agendaEvents(start), nil
+	}
+
+	var out bytes.Buffer
+	warning, err := PrintAgenda(&out, 7)
+	if err != nil || warning != "" {
+		t.Fatalf("PrintAgenda() = (%q, %v)", warning, err)
+	}
+	if got := out.String(); !strings.Contains(got, "Standup") || strings.Contains(got, "\x1b[") {
+		t.Errorf("Expected a plain list without colors, got %q", got)
+	}
+}
@@ -0,0 +1,60 @@
+package calendar
+
+import "time"
+
+// windowKey identifies a visible range by its first day and length. The
+// location is part of the key since days start at midnight in the primary
+// zone, which changes when zones are swapped, and the length since a week
+// without weekends starts on the same day as the whole week.
+type windowKey struct {
+	start int64
+	days  int
+	loc   *time.Location
+}
+
+func keyFor(start time.Time, days int) windowKey {
+	return windowKey{start: start.Unix(), days: days, loc: start.Location()}
+}
+
+// cachedWindow is the last fetch result for a range.
+type cachedWindow struct {
+	start   time.Time
+	events  []CalendarEvent
+	warning string
+	updated time.Time
+}
+
+// windowCache holds the events of recently visited and prefetched ranges so
+// navigation can show them instantly while they are fetched again.
+type windowCache map[windowKey]cachedWindow
+
+// cacheReach is how many periods on either side of the visible range are
+// kept in the cache.
+const cacheReach = 2
+
+func (c windowCache) get(start time.Time, days int) (cachedWindow, bool) {
+	w, ok := c[keyFor(start, days)]
+	return w, ok
+}
+
+// put stores the events for the days from start, returning the cache since a
+// nil cache is allocated on first use.
+func (c windowCache) put(start time.Time, days int, events []CalendarEvent, warning string, updated time.Time) windowCache {
+	if c == nil {
+		c = windowCache{}
+	}
+	c[keyFor(start, days)] = cachedWindow{start: start, events: events, warning: warning, updated: updated}
+	return c
+}
+
+// prune drops ranges more than cacheReach periods of days away from start,
+// and any that were parsed into another time zone.
+func (c windowCache) prune(start time.Time, period int) {
+	from := start.AddDate(0, 0, -cacheReach*period)
+	to := start.AddDate(0, 0, cacheReach*period)
+	for key, w := range c {
+		if w.start.Location() != start.Location() || w.start.Before(from) || w.start.After(to) {
+			delete(c, key)
+		}
+	}
+}
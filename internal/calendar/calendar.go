@@ -3,14 +3,12 @@ package calendar
 
 import (
 	"fmt"
-	"sync"
+	"strings"
 	"time"
 
 	"github.com/kahnwong/gcal-tui/internal/utils"
 
-	cliBase "github.com/kahnwong/cli-base"
 	"github.com/kahnwong/gcal-tui/configs"
-	"github.com/kahnwong/gcal-tui/internal/gcal"
 	"google.golang.org/api/calendar/v3"
 )
 
@@ -18,16 +16,161 @@ type CalendarEvent struct {
 	Title     string
 	StartTime time.Time
 	EndTime   time.Time
-	Color     string
+	Color     string // color name or "#rrggbb" hex, see GetColorValue
+	TextColor string // foreground on top of Color, empty for the default
+	AllDay    bool   // date-only event, EndTime is exclusive midnight
+
+	ID               string
+	ICalUID          string // shared by copies of the event in other calendars
+	CalendarID       string
+	CalendarName     string
+	AccountName      string
+	Location         string
+	Description      string
+	Organizer        Attendee
+	Attendees        []Attendee
+	Conference       *Conference
+	JoinURL          string // Meet, Zoom, Teams or Webex link, see joinURL
+	HTMLLink         string
+	Status           string // confirmed, tentative or cancelled
+	Transparency     string // opaque (busy) or transparent (free)
+	Visibility       string
+	RecurringEventID string
+	EventType        string // EventTypeDefault, EventTypeOutOfOffice, ...
+	WorkingLocation  string // label of a working location event, e.g. "Home"
+
+	Display string   // configs.FilterShow, FilterDim or FilterHide, see ApplyFilter
+	Sources []string // calendars the event was seen in, see DedupeEvents
+}
+
+// Event types as reported by Google Calendar
+const (
+	EventTypeDefault         = "default"
+	EventTypeOutOfOffice     = "outOfOffice"
+	EventTypeFocusTime       = "focusTime"
+	EventTypeWorkingLocation = "workingLocation"
+)
+
+// Attendee is a guest or the organizer of an event.
+type Attendee struct {
+	Email          string
+	DisplayName    string
+	ResponseStatus string // needsAction, declined, tentative or accepted
+	Self           bool
+	Organizer      bool
+	Optional       bool
+}
+
+// Name returns the display name, falling back to the email address.
+func (a Attendee) Name() string {
+	if a.DisplayName != "" {
+		return a.DisplayName
+	}
+	return a.Email
+}
+
+// Conference describes how to join an event, e.g. a Google Meet call.
+type Conference struct {
+	Solution    string // e.g. "Google Meet"
+	EntryPoints []ConferenceEntryPoint
+}
+
+// ConferenceEntryPoint is one way of joining a conference.
+type ConferenceEntryPoint struct {
+	Type  string // video, phone, sip or more
+	URI   string
+	Label string
+}
+
+// ColorPair is a background/foreground combination, as used by Google for
+// calendars and events.
+type ColorPair struct {
+	Background string
+	Foreground string
+}
+
+// CalendarColors holds the colors used when rendering events of one calendar.
+type CalendarColors struct {
+	Calendar ColorPair            // default for events without a colorId
+	Event    map[string]ColorPair // Google event palette, keyed by colorId
+}
+
+// ResolveCalendarColors picks the colors for a calendar. A color set
+// explicitly in the config wins, then the calendar's own color from
+// CalendarList; entry and palette may be nil when they could not be fetched.
+func ResolveCalendarColors(configColor string, entry *calendar.CalendarListEntry, palette *calendar.Colors) CalendarColors {
+	var colors CalendarColors
+
+	if configColor != "" {
+		colors.Calendar = ColorPair{Background: configColor}
+	} else if entry != nil {
+		colors.Calendar = ColorPair{Background: entry.BackgroundColor, Foreground: entry.ForegroundColor}
+	}
+
+	if palette != nil {
+		colors.Event = make(map[string]ColorPair, len(palette.Event))
+		for id, definition := range palette.Event {
+			colors.Event[id] = ColorPair{Background: definition.Background, Foreground: definition.Foreground}
+		}
+	}
+
+	return colors
 }
 
-func ParseCalendars(color string, events *calendar.Events) ([]CalendarEvent, error) {
+func ParseCalendars(colors CalendarColors, events *calendar.Events) ([]CalendarEvent, error) {
 	var calendarEvents []CalendarEvent
 
 	for _, item := range events.Items {
+		if !hasTimes(item) {
+			if item.Status == "cancelled" {
+				// deleted events may only carry their id when cancelled
+				// events are asked for
+				continue
+			}
+			return nil, fmt.Errorf("event '%s' has no start or end time/date", item.Summary)
+		}
+
+		color := colors.Calendar
+		if eventColor, ok := colors.Event[item.ColorId]; ok && item.ColorId != "" {
+			color = eventColor
+		}
+
 		event := CalendarEvent{
-			Title: item.Summary,
-			Color: color,
+			Title:            item.Summary,
+			Color:            color.Background,
+			TextColor:        color.Foreground,
+			ID:               item.Id,
+			ICalUID:          item.ICalUID,
+			CalendarName:     events.Summary,
+			Location:         item.Location,
+			Description:      item.Description,
+			HTMLLink:         item.HtmlLink,
+			Status:           item.Status,
+			Transparency:     item.Transparency,
+			Visibility:       item.Visibility,
+			RecurringEventID: item.RecurringEventId,
+			EventType:        item.EventType,
+			Conference:       parseConference(item.ConferenceData),
+			WorkingLocation:  parseWorkingLocation(item.WorkingLocationProperties),
+		}
+		event.JoinURL = joinURL(event.Conference, item.HangoutLink, item.Location, item.Description)
+		if item.Organizer != nil {
+			event.Organizer = Attendee{
+				Email:       item.Organizer.Email,
+				DisplayName: item.Organizer.DisplayName,
+				Self:        item.Organizer.Self,
+				Organizer:   true,
+			}
+		}
+		for _, attendee := range item.Attendees {
+			event.Attendees = append(event.Attendees, Attendee{
+				Email:          attendee.Email,
+				DisplayName:    attendee.DisplayName,
+				ResponseStatus: attendee.ResponseStatus,
+				Self:           attendee.Self,
+				Organizer:      attendee.Organizer,
+				Optional:       attendee.Optional,
+			})
 		}
 
 		// Handle all-day events vs. timed events
@@ -37,42 +180,33 @@ func ParseCalendars(color string, events *calendar.Events) ([]CalendarEvent, err
 			if err != nil {
 				return nil, fmt.Errorf("error parsing start time for event '%s': %w", item.Summary, err)
 			}
-			event.StartTime = startTime
+			event.StartTime = startTime.In(utils.Location)
 
 			endTime, err := time.Parse(time.RFC3339, item.End.DateTime)
 			if err != nil {
 				return nil, fmt.Errorf("error parsing end time for event '%s': %w", item.Summary, err)
 			}
-			event.EndTime = endTime
-		} else if item.Start.Date != "" {
-			//// All-day event
-			//// For all-day events, the API returns "YYYY-MM-DD".
-			//// We can interpret this as the start of the day in UTC, or the local timezone
-			//// depending on requirements. For simplicity, we'll parse it as a date and
-			//// set time to midnight. Note that Google Calendar's all-day events
-			//// endDate is exclusive, so it might be the next day.
-			//startDate, err := time.Parse("2006-01-02", item.Start.Date)
-			//if err != nil {
-			//	return nil, fmt.Errorf("error parsing all-day start date for event '%s': %w", item.Summary, err)
-			//}
-			//event.StartTime = startDate
-			//
-			//endDate, err := time.Parse("2006-01-02", item.End.Date)
-			//if err != nil {
-			//	return nil, fmt.Errorf("error parsing all-day end date for event '%s': %w", item.Summary, err)
-			//}
-			//// For all-day events, Google Calendar's end date is exclusive.
-			//// To represent the end of the last day, subtract a nanosecond.
-			//event.EndTime = endDate.Add(-time.Nanosecond)
+			event.EndTime = endTime.In(utils.Location)
 		} else {
-			return nil, fmt.Errorf("event '%s' has no start or end time/date", item.Summary)
-		}
-
-		// time adjustment
-		_, startTimeOffsetSeconds := event.StartTime.Zone()
+			// All-day event: the API returns "YYYY-MM-DD" and the end date is
+			// exclusive, so a single-day event ends at midnight of the next day.
+			event.AllDay = true
+			startDate, err := time.ParseInLocation(time.DateOnly, item.Start.Date, utils.Location)
+			if err != nil {
+				return nil, fmt.Errorf("error parsing all-day start date for event '%s': %w", item.Summary, err)
+			}
+			event.StartTime = startDate
 
-		event.StartTime = event.StartTime.Add(time.Second * time.Duration(startTimeOffsetSeconds))
-		event.EndTime = event.EndTime.Add(time.Second * time.Duration(startTimeOffsetSeconds))
+			if item.End == nil || item.End.Date == "" {
+				event.EndTime = startDate.AddDate(0, 0, 1)
+			} else {
+				endDate, err := time.ParseInLocation(time.DateOnly, item.End.Date, utils.Location)
+				if err != nil {
+					return nil, fmt.Errorf("error parsing all-day end date for event '%s': %w", item.Summary, err)
+				}
+				event.EndTime = endDate
+			}
+		}
 
 		calendarEvents = append(calendarEvents, event)
 	}
@@ -80,113 +214,141 @@ func ParseCalendars(color string, events *calendar.Events) ([]CalendarEvent, err
 	return calendarEvents, nil
 }
 
-func FetchAllEvents(weekStart time.Time) ([]CalendarEvent, error) {
-	var allEvents []CalendarEvent
-
-	resultsCh := make(chan []CalendarEvent, 100) // Buffer size can be tuned
-	errorsCh := make(chan error, 100)
-	var accountsWg sync.WaitGroup
-	for _, c := range configs.AppConfig.Accounts {
-		accountsWg.Add(1)
-		go func(account configs.Account) {
-			defer accountsWg.Done()
-			expandedPath, err := cliBase.ExpandHome(account.Credentials)
-			if err != nil {
-				errorsCh <- fmt.Errorf("failed to expand home path for account '%s': %w", account.Name, err)
-				return
-			}
-			oathClientIDJson, err := gcal.ReadOauthClientID(expandedPath)
-			if err != nil {
-				errorsCh <- fmt.Errorf("failed to read OAuth client ID for account '%s': %w", account.Name, err)
-				return
-			}
-			client, err := gcal.GetClient(account.Name, oathClientIDJson)
-			if err != nil {
-				errorsCh <- fmt.Errorf("failed to get client for account '%s': %w", account.Name, err)
-				return
-			}
+// hasTimes reports whether item says when it takes place, as a start and end
+// time or at least a start date.
+func hasTimes(item *calendar.Event) bool {
+	if item.Start == nil {
+		return false
+	}
+	if item.Start.DateTime != "" {
+		return item.End != nil
+	}
+	return item.Start.Date != ""
+}
 
-			var calendarsWg sync.WaitGroup
-			for _, calendarInfo := range account.Calendars {
-				calendarsWg.Add(1)
-				go func(calInfo configs.Calendar) {
-					defer calendarsWg.Done()
-					events, err := gcal.GetEvents(weekStart, calInfo.Id, client)
-					if err != nil {
-						errorsCh <- fmt.Errorf("failed to get events for calendar '%s': %w", calInfo.Id, err)
-						return
-					}
-					calendarEvents, err := ParseCalendars(calInfo.Color, events)
-					if err != nil {
-						errorsCh <- fmt.Errorf("failed to parse calendars for calendar '%s': %w", calInfo.Id, err)
-						return
-					}
-					resultsCh <- calendarEvents
-				}(calendarInfo)
-			}
-			calendarsWg.Wait()
-		}(c)
-	}
-
-	go func() {
-		accountsWg.Wait()
-		close(resultsCh)
-		close(errorsCh)
-	}()
-
-	// Collect results and errors
-	var errors []error
-	done := make(chan struct{})
-	go func() {
-		for events := range resultsCh {
-			allEvents = append(allEvents, events...)
-		}
-		close(done)
-	}()
+func parseConference(data *calendar.ConferenceData) *Conference {
+	if data == nil {
+		return nil
+	}
 
-	for err := range errorsCh {
-		errors = append(errors, err)
+	conference := &Conference{}
+	if data.ConferenceSolution != nil {
+		conference.Solution = data.ConferenceSolution.Name
 	}
+	for _, entryPoint := range data.EntryPoints {
+		conference.EntryPoints = append(conference.EntryPoints, ConferenceEntryPoint{
+			Type:  entryPoint.EntryPointType,
+			URI:   entryPoint.Uri,
+			Label: entryPoint.Label,
+		})
+	}
+	return conference
+}
 
-	<-done
+func parseWorkingLocation(properties *calendar.EventWorkingLocationProperties) string {
+	if properties == nil {
+		return ""
+	}
 
-	// Return first error if any occurred
-	if len(errors) > 0 {
-		return nil, errors[0]
+	switch properties.Type {
+	case "homeOffice":
+		return "Home"
+	case "officeLocation":
+		if properties.OfficeLocation != nil && properties.OfficeLocation.Label != "" {
+			return properties.OfficeLocation.Label
+		}
+		return "Office"
+	case "customLocation":
+		if properties.CustomLocation != nil {
+			return properties.CustomLocation.Label
+		}
 	}
+	return ""
+}
 
-	// for making current time in calendar
-	now := roundToNearestHalfHour(utils.GetNowLocalAdjusted())
-	allEvents = append(allEvents, CalendarEvent{
-		Title:     "CURRENT TIME",
-		StartTime: now,
-		EndTime:   now.Add(time.Minute * 30),
-		Color:     "red",
-	})
+// SourceName names the calendar an event came from.
+func (e CalendarEvent) SourceName() string {
+	if e.CalendarName != "" {
+		return e.CalendarName
+	}
+	return e.CalendarID
+}
 
-	return allEvents, nil
+// DisplayTitle is the title shown in the views, listing the source calendars
+// when the event was merged from several of them.
+func (e CalendarEvent) DisplayTitle() string {
+	if len(e.Sources) > 1 {
+		return fmt.Sprintf("%s ⧉ %s", e.Title, strings.Join(e.Sources, ", "))
+	}
+	return e.Title
 }
 
-func roundToNearestHalfHour(t time.Time) time.Time {
-	minute := t.Minute()
-	second := t.Second()
-	nanosecond := t.Nanosecond()
+// DedupeEvents collapses copies of the same meeting seen through several
+// calendars or accounts, matched by iCalUID and start time. A copy that is not
+// hidden is kept over one that is, then one that is not dimmed, then the one
+// with the lowest rank. Sources lists every calendar it was seen in.
+func DedupeEvents(events []CalendarEvent, rank func(CalendarEvent) int) []CalendarEvent {
+	type key struct {
+		uid   string
+		start int64
+	}
+	seen := make(map[key]int)
 
-	// Calculate total minutes past the hour, including seconds and nanoseconds as fractions of a minute
-	totalMinutes := float64(minute) + float64(second)/60.0 + float64(nanosecond)/(60.0*1e9)
+	var deduped []CalendarEvent
+	for _, e := range events {
+		e.Sources = []string{e.SourceName()}
+		if e.ICalUID == "" {
+			deduped = append(deduped, e)
+			continue
+		}
 
-	var roundedTime time.Time
+		k := key{uid: e.ICalUID, start: e.StartTime.Unix()}
+		i, ok := seen[k]
+		if !ok {
+			seen[k] = len(deduped)
+			deduped = append(deduped, e)
+			continue
+		}
 
-	if totalMinutes >= 45 {
-		// Round up to the next hour
-		roundedTime = t.Truncate(time.Hour).Add(time.Hour)
-	} else if totalMinutes >= 15 {
-		// Round to :30
-		roundedTime = t.Truncate(time.Hour).Add(30 * time.Minute)
-	} else {
-		// Round down to :00
-		roundedTime = t.Truncate(time.Hour)
+		sources := append(deduped[i].Sources, e.Sources...)
+		if preferredCopy(e, deduped[i], rank) {
+			deduped[i] = e
+		}
+		deduped[i].Sources = sources
 	}
+	return deduped
+}
 
-	return roundedTime
+// preferredCopy reports whether e should be kept over kept, another copy of
+// the same meeting.
+func preferredCopy(e, kept CalendarEvent, rank func(CalendarEvent) int) bool {
+	if e.Hidden() != kept.Hidden() {
+		return !e.Hidden()
+	}
+	if e.Dimmed() != kept.Dimmed() {
+		return !e.Dimmed()
+	}
+	return rank(e) < rank(kept)
+}
+
+// configRank orders events by the position of their calendar in the config,
+// with preferred calendars first.
+func configRank(config *configs.Config) func(CalendarEvent) int {
+	type key struct{ account, calendar string }
+	ranks := make(map[key]int)
+	if config != nil {
+		for _, account := range config.Accounts {
+			for _, calendarInfo := range account.Calendars {
+				rank := len(ranks)
+				if calendarInfo.Preferred {
+					rank = -1
+				}
+				ranks[key{account.Name, calendarInfo.Id}] = rank
+			}
+		}
+	}
+
+	return func(e CalendarEvent) int {
+		return ranks[key{e.AccountName, e.CalendarID}]
+	}
 }
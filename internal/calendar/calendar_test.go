@@ -6,6 +6,8 @@ import (
 	"time"
 
 	"charm.land/lipgloss/v2"
+	"github.com/kahnwong/gcal-tui/configs"
+	"github.com/kahnwong/gcal-tui/internal/utils"
 	"google.golang.org/api/calendar/v3"
 )
 
@@ -19,6 +21,7 @@ func TestGetColorValue(t *testing.T) {
 		{"teal color", "teal", lipgloss.Color("#008080")},
 		{"green color", "green", lipgloss.Color("#00FF00")},
 		{"red color", "red", lipgloss.Color("#FF0000")},
+		{"hex color from google", "#9fe1e7", lipgloss.Color("#9fe1e7")},
 		{"unknown color defaults to orange", "unknown", lipgloss.Color("#FFA500")},
 		{"empty string defaults to orange", "", lipgloss.Color("#FFA500")},
 	}
@@ -33,69 +36,6 @@ func TestGetColorValue(t *testing.T) {
 	}
 }
 
-func TestRoundToNearestHalfHour(t *testing.T) {
-	tests := []struct {
-		name     string
-		input    time.Time
-		expected time.Time
-	}{
-		{
-			name:     "round down to :00 from :05",
-			input:    time.Date(2026, 1, 31, 10, 5, 0, 0, time.UTC),
-			expected: time.Date(2026, 1, 31, 10, 0, 0, 0, time.UTC),
-		},
-		{
-			name:     "round down to :00 from :14",
-			input:    time.Date(2026, 1, 31, 10, 14, 59, 0, time.UTC),
-			expected: time.Date(2026, 1, 31, 10, 0, 0, 0, time.UTC),
-		},
-		{
-			name:     "round to :30 from :15",
-			input:    time.Date(2026, 1, 31, 10, 15, 0, 0, time.UTC),
-			expected: time.Date(2026, 1, 31, 10, 30, 0, 0, time.UTC),
-		},
-		{
-			name:     "round to :30 from :25",
-			input:    time.Date(2026, 1, 31, 10, 25, 0, 0, time.UTC),
-			expected: time.Date(2026, 1, 31, 10, 30, 0, 0, time.UTC),
-		},
-		{
-			name:     "round to :30 from :44",
-			input:    time.Date(2026, 1, 31, 10, 44, 59, 0, time.UTC),
-			expected: time.Date(2026, 1, 31, 10, 30, 0, 0, time.UTC),
-		},
-		{
-			name:     "round up to next hour from :45",
-			input:    time.Date(2026, 1, 31, 10, 45, 0, 0, time.UTC),
-			expected: time.Date(2026, 1, 31, 11, 0, 0, 0, time.UTC),
-		},
-		{
-			name:     "round up to next hour from :55",
-			input:    time.Date(2026, 1, 31, 10, 55, 0, 0, time.UTC),
-			expected: time.Date(2026, 1, 31, 11, 0, 0, 0, time.UTC),
-		},
-		{
-			name:     "already at :00",
-			input:    time.Date(2026, 1, 31, 10, 0, 0, 0, time.UTC),
-			expected: time.Date(2026, 1, 31, 10, 0, 0, 0, time.UTC),
-		},
-		{
-			name:     "already at :30",
-			input:    time.Date(2026, 1, 31, 10, 30, 0, 0, time.UTC),
-			expected: time.Date(2026, 1, 31, 10, 30, 0, 0, time.UTC),
-		},
-	}
-
-	for _, tt := range tests {
-		t.Run(tt.name, func(t *testing.T) {
-			result := roundToNearestHalfHour(tt.input)
-			if !result.Equal(tt.expected) {
-				t.Errorf("roundToNearestHalfHour(%v) = %v, want %v", tt.input, result, tt.expected)
-			}
-		})
-	}
-}
-
 func TestParseCalendars(t *testing.T) {
 	tests := []struct {
 		name        string
@@ -164,7 +104,7 @@ func TestParseCalendars(t *testing.T) {
 
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			result, err := ParseCalendars(tt.color, tt.events)
+			result, err := ParseCalendars(CalendarColors{Calendar: ColorPair{Background: tt.color}}, tt.events)
 
 			if tt.expectError && err == nil {
 				t.Error("Expected error but got none")
@@ -187,3 +127,354 @@ func TestParseCalendars(t *testing.T) {
 		})
 	}
 }
+
+func TestResolveCalendarColors(t *testing.T) {
+	entry := &calendar.CalendarListEntry{BackgroundColor: "#9fe1e7", ForegroundColor: "#000000"}
+	palette := &calendar.Colors{
+		Event: map[string]calendar.ColorDefinition{
+			"11": {Background: "#dc2127", Foreground: "#1d1d1d"},
+		},
+	}
+
+	t.Run("explicit config color wins over calendar color", func(t *testing.T) {
+		colors := ResolveCalendarColors("teal", entry, palette)
+		if colors.Calendar != (ColorPair{Background: "teal"}) {
+			t.Errorf("Expected config color, got %+v", colors.Calendar)
+		}
+	})
+
+	t.Run("calendar color is used when config color is empty", func(t *testing.T) {
+		colors := ResolveCalendarColors("", entry, palette)
+		if colors.Calendar != (ColorPair{Background: "#9fe1e7", Foreground: "#000000"}) {
+			t.Errorf("Expected calendar list color, got %+v", colors.Calendar)
+		}
+	})
+
+	t.Run("missing entry and palette fall back to defaults", func(t *testing.T) {
+		colors := ResolveCalendarColors("", nil, nil)
+		if colors.Calendar != (ColorPair{}) {
+			t.Errorf("Expected empty color pair, got %+v", colors.Calendar)
+		}
+		if len(colors.Event) != 0 {
+			t.Errorf("Expected no event colors, got %d", len(colors.Event))
+		}
+	})
+
+	t.Run("event palette is copied", func(t *testing.T) {
+		colors := ResolveCalendarColors("", entry, palette)
+		if colors.Event["11"] != (ColorPair{Background: "#dc2127", Foreground: "#1d1d1d"}) {
+			t.Errorf("Expected event color 11, got %+v", colors.Event["11"])
+		}
+	})
+}
+
+func TestParseCalendarsEventColorId(t *testing.T) {
+	colors := CalendarColors{
+		Calendar: ColorPair{Background: "#9fe1e7", Foreground: "#000000"},
+		Event:    map[string]ColorPair{"11": {Background: "#dc2127", Foreground: "#1d1d1d"}},
+	}
+	events := &calendar.Events{
+		Items: []*calendar.Event{
+			{
+				Summary: "Colored",
+				ColorId: "11",
+				Start:   &calendar.EventDateTime{DateTime: "2026-01-31T10:00:00Z"},
+				End:     &calendar.EventDateTime{DateTime: "2026-01-31T11:00:00Z"},
+			},
+			{
+				Summary: "Unknown color id",
+				ColorId: "99",
+				Start:   &calendar.EventDateTime{DateTime: "2026-01-31T12:00:00Z"},
+				End:     &calendar.EventDateTime{DateTime: "2026-01-31T13:00:00Z"},
+			},
+		},
+	}
+
+	result, err := ParseCalendars(colors, events)
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	if result[0].Color != "#dc2127" || result[0].TextColor != "#1d1d1d" {
+		t.Errorf("Expected event color to win, got %q/%q", result[0].Color, result[0].TextColor)
+	}
+	if result[1].Color != "#9fe1e7" || result[1].TextColor != "#000000" {
+		t.Errorf("Expected calendar color for unknown colorId, got %q/%q", result[1].Color, result[1].TextColor)
+	}
+}
+
+func TestParseCalendarsAllDay(t *testing.T) {
+	events := &calendar.Events{
+		Items: []*calendar.Event{
+			{
+				Summary: "Holiday",
+				Start:   &calendar.EventDateTime{Date: "2026-01-31"},
+				End:     &calendar.EventDateTime{Date: "2026-02-01"},
+			},
+			{
+				Summary: "Conference",
+				Start:   &calendar.EventDateTime{Date: "2026-02-02"},
+				End:     &calendar.EventDateTime{Date: "2026-02-05"},
+			},
+		},
+	}
+
+	result, err := ParseCalendars(CalendarColors{}, events)
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if len(result) != 2 {
+		t.Fatalf("Expected 2 events, got %d", len(result))
+	}
+
+	tests := []struct {
+		name      string
+		event     CalendarEvent
+		wantStart time.Time
+		wantEnd   time.Time
+	}{
+		{"single day", result[0], time.Date(2026, 1, 31, 0, 0, 0, 0, utils.Location), time.Date(2026, 2, 1, 0, 0, 0, 0, utils.Location)},
+		{"multi day keeps exclusive end", result[1], time.Date(2026, 2, 2, 0, 0, 0, 0, utils.Location), time.Date(2026, 2, 5, 0, 0, 0, 0, utils.Location)},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if !tt.event.AllDay {
+				t.Error("Expected AllDay to be set")
+			}
+			if !tt.event.StartTime.Equal(tt.wantStart) {
+				t.Errorf("StartTime = %v, want %v", tt.event.StartTime, tt.wantStart)
+			}
+			if !tt.event.EndTime.Equal(tt.wantEnd) {
+				t.Errorf("EndTime = %v, want %v", tt.event.EndTime, tt.wantEnd)
+			}
+		})
+	}
+}
+
+func TestParseCalendarsDeletedEvents(t *testing.T) {
+	events := &calendar.Events{
+		Items: []*calendar.Event{
+			{Id: "deleted", Status: "cancelled"},
+			{Id: "deleted-timed", Status: "cancelled", Start: &calendar.EventDateTime{DateTime: "2026-01-31T10:00:00Z"}},
+			{
+				Summary: "Cancelled standup",
+				Status:  "cancelled",
+				Start:   &calendar.EventDateTime{DateTime: "2026-01-31T09:00:00Z"},
+				End:     &calendar.EventDateTime{DateTime: "2026-01-31T09:15:00Z"},
+			},
+		},
+	}
+
+	result, err := ParseCalendars(CalendarColors{}, events)
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if len(result) != 1 || result[0].Title != "Cancelled standup" {
+		t.Errorf("Expected only the cancelled event with times, got %+v", result)
+	}
+
+	_, err = ParseCalendars(CalendarColors{}, &calendar.Events{Items: []*calendar.Event{{Summary: "Broken"}}})
+	if err == nil {
+		t.Error("Expected an error for a confirmed event without times")
+	}
+}
+
+func TestParseCalendarsDetails(t *testing.T) {
+	events := &calendar.Events{
+		Summary: "Work",
+		Items: []*calendar.Event{
+			{
+				Id:               "abc123_20260131T100000Z",
+				Summary:          "Design review",
+				Location:         "Room 4",
+				Description:      "Agenda in the doc",
+				HtmlLink:         "https://www.google.com/calendar/event?eid=abc",
+				Status:           "confirmed",
+				Transparency:     "opaque",
+				Visibility:       "private",
+				RecurringEventId: "abc123",
+				EventType:        "default",
+				Organizer:        &calendar.EventOrganizer{Email: "lead@example.com", DisplayName: "Lead"},
+				Attendees: []*calendar.EventAttendee{
+					{Email: "me@example.com", ResponseStatus: "accepted", Self: true},
+					{Email: "guest@example.com", ResponseStatus: "declined", Optional: true},
+				},
+				ConferenceData: &calendar.ConferenceData{
+					ConferenceSolution: &calendar.ConferenceSolution{Name: "Google Meet"},
+					EntryPoints: []*calendar.EntryPoint{
+						{EntryPointType: "video", Uri: "https://meet.google.com/abc-defg-hij", Label: "meet.google.com/abc-defg-hij"},
+					},
+				},
+				Start: &calendar.EventDateTime{DateTime: "2026-01-31T10:00:00Z"},
+				End:   &calendar.EventDateTime{DateTime: "2026-01-31T11:00:00Z"},
+			},
+		},
+	}
+
+	result, err := ParseCalendars(CalendarColors{}, events)
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	e := result[0]
+
+	if e.ID != "abc123_20260131T100000Z" || e.RecurringEventID != "abc123" {
+		t.Errorf("Unexpected IDs: %q, %q", e.ID, e.RecurringEventID)
+	}
+	if e.CalendarName != "Work" {
+		t.Errorf("Expected calendar name 'Work', got %q", e.CalendarName)
+	}
+	if e.Location != "Room 4" || e.Description != "Agenda in the doc" || e.HTMLLink == "" {
+		t.Errorf("Unexpected details: %+v", e)
+	}
+	if e.Status != "confirmed" || e.Transparency != "opaque" || e.Visibility != "private" || e.EventType != "default" {
+		t.Errorf("Unexpected status fields: %q, %q, %q, %q", e.Status, e.Transparency, e.Visibility, e.EventType)
+	}
+	if e.Organizer.Name() != "Lead" || !e.Organizer.Organizer {
+		t.Errorf("Unexpected organizer: %+v", e.Organizer)
+	}
+	if len(e.Attendees) != 2 {
+		t.Fatalf("Expected 2 attendees, got %d", len(e.Attendees))
+	}
+	if !e.Attendees[0].Self || e.Attendees[0].ResponseStatus != "accepted" {
+		t.Errorf("Unexpected first attendee: %+v", e.Attendees[0])
+	}
+	if e.Attendees[1].Name() != "guest@example.com" || e.Attendees[1].ResponseStatus != "declined" || !e.Attendees[1].Optional {
+		t.Errorf("Unexpected second attendee: %+v", e.Attendees[1])
+	}
+	if e.Conference == nil || e.Conference.Solution != "Google Meet" || len(e.Conference.EntryPoints) != 1 {
+		t.Fatalf("Unexpected conference: %+v", e.Conference)
+	}
+	if e.Conference.EntryPoints[0].URI != "https://meet.google.com/abc-defg-hij" {
+		t.Errorf("Unexpected entry point: %+v", e.Conference.EntryPoints[0])
+	}
+}
+
+func TestDedupeEvents(t *testing.T) {
+	start := time.Date(2026, 1, 31, 10, 0, 0, 0, time.UTC)
+	config := &configs.Config{
+		Accounts: []configs.Account{
+			{Name: "personal", Calendars: []configs.Calendar{{Id: "primary"}}},
+			{Name: "work", Calendars: []configs.Calendar{{Id: "primary", Preferred: true}, {Id: "team@group.calendar.google.com"}}},
+		},
+	}
+
+	events := []CalendarEvent{
+		{Title: "Sync", ICalUID: "sync@google.com", StartTime: start, AccountName: "personal", CalendarID: "primary", CalendarName: "me@gmail.com", Color: "aqua"},
+		{Title: "Sync", ICalUID: "sync@google.com", StartTime: start, AccountName: "work", CalendarID: "primary", CalendarName: "me@work.com", Color: "teal"},
+		{Title: "Sync", ICalUID: "sync@google.com", StartTime: start, AccountName: "work", CalendarID: "team@group.calendar.google.com", CalendarName: "Team", Color: "green"},
+		{Title: "Sync", ICalUID: "sync@google.com", StartTime: start.AddDate(0, 0, 7), AccountName: "personal", CalendarID: "primary", CalendarName: "me@gmail.com"},
+		{Title: "No UID", StartTime: start, AccountName: "personal", CalendarID: "primary"},
+		{Title: "No UID", StartTime: start, AccountName: "work", CalendarID: "primary"},
+	}
+
+	result := DedupeEvents(events, configRank(config))
+	if len(result) != 4 {
+		t.Fatalf("Expected 4 events, got %d", len(result))
+	}
+
+	merged := result[0]
+	if merged.Color != "teal" || merged.AccountName != "work" {
+		t.Errorf("Expected the preferred calendar's copy to be kept, got %+v", merged)
+	}
+	if len(merged.Sources) != 3 {
+		t.Errorf("Expected 3 sources, got %q", merged.Sources)
+	}
+	if title := merged.DisplayTitle(); title != "Sync ⧉ me@gmail.com, me@work.com, Team" {
+		t.Errorf("Unexpected display title %q", title)
+	}
+
+	if len(result[1].Sources) != 1 || result[1].DisplayTitle() != "Sync" {
+		t.Errorf("Expected a different occurrence to stay separate, got %+v", result[1])
+	}
+}
+
+func TestDedupeEventsPrefersVisibleCopies(t *testing.T) {
+	start := time.Date(2026, 1, 31, 10, 0, 0, 0, time.UTC)
+	copyIn := func(calendarId, display string) CalendarEvent {
+		return CalendarEvent{Title: "Sync", ICalUID: "sync@google.com", StartTime: start, CalendarID: calendarId, Display: display}
+	}
+	rank := func(e CalendarEvent) int { return map[string]int{"a": 0, "b": 1, "c": 2}[e.CalendarID] }
+
+	tests := []struct {
+		name   string
+		events []CalendarEvent
+		want   string
+	}{
+		{"not hidden over rank", []CalendarEvent{copyIn("a", configs.FilterHide), copyIn("b", configs.FilterDim), copyIn("c", configs.FilterShow)}, "c"},
+		{"not dimmed over rank", []CalendarEvent{copyIn("a", configs.FilterDim), copyIn("c", configs.FilterShow), copyIn("b", configs.FilterShow)}, "b"},
+		{"dimmed over hidden", []CalendarEvent{copyIn("a", configs.FilterHide), copyIn("c", configs.FilterDim)}, "c"},
+		{"rank among hidden", []CalendarEvent{copyIn("c", configs.FilterHide), copyIn("a", configs.FilterHide)}, "a"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result := DedupeEvents(tt.events, rank)
+			if len(result) != 1 || result[0].CalendarID != tt.want {
+				t.Errorf("Expected the copy from %s to be kept, got %+v", tt.want, result)
+			}
+		})
+	}
+}
+
+func TestParseCalendarsTimezone(t *testing.T) {
+	bangkok, err := utils.LoadLocation("Asia/Bangkok")
+	if err != nil {
+		t.Fatalf("Failed to load location: %v", err)
+	}
+	previous := utils.Location
+	utils.Location = bangkok
+	t.Cleanup(func() { utils.Location = previous })
+
+	events := &calendar.Events{
+		Items: []*calendar.Event{
+			{
+				Summary: "Berlin standup",
+				Start:   &calendar.EventDateTime{DateTime: "2026-01-31T10:00:00+01:00"},
+				End:     &calendar.EventDateTime{DateTime: "2026-01-31T10:15:00+01:00"},
+			},
+			{
+				Summary: "Holiday",
+				Start:   &calendar.EventDateTime{Date: "2026-02-01"},
+				End:     &calendar.EventDateTime{Date: "2026-02-02"},
+			},
+		},
+	}
+
+	result, err := ParseCalendars(CalendarColors{}, events)
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	standup := result[0]
+	if standup.StartTime.Location() != bangkok || standup.StartTime.Hour() != 16 {
+		t.Errorf("Expected 16:00 in Bangkok, got %v", standup.StartTime)
+	}
+	if !standup.StartTime.Equal(time.Date(2026, 1, 31, 9, 0, 0, 0, time.UTC)) {
+		t.Errorf("Expected the instant to be unchanged, got %v", standup.StartTime)
+	}
+
+	holiday := result[1]
+	if !holiday.StartTime.Equal(time.Date(2026, 2, 1, 0, 0, 0, 0, bangkok)) {
+		t.Errorf("Expected all-day event to start at midnight in Bangkok, got %v", holiday.StartTime)
+	}
+}
+
+func TestParseWorkingLocation(t *testing.T) {
+	tests := []struct {
+		name       string
+		properties *calendar.EventWorkingLocationProperties
+		expected   string
+	}{
+		{"not a working location", nil, ""},
+		{"home", &calendar.EventWorkingLocationProperties{Type: "homeOffice"}, "Home"},
+		{"office with label", &calendar.EventWorkingLocationProperties{Type: "officeLocation", OfficeLocation: &calendar.EventWorkingLocationPropertiesOfficeLocation{Label: "BKK-2"}}, "BKK-2"},
+		{"office without label", &calendar.EventWorkingLocationProperties{Type: "officeLocation"}, "Office"},
+		{"custom", &calendar.EventWorkingLocationProperties{Type: "customLocation", CustomLocation: &calendar.EventWorkingLocationPropertiesCustomLocation{Label: "Cafe"}}, "Cafe"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if result := parseWorkingLocation(tt.properties); result != tt.expected {
+				t.Errorf("parseWorkingLocation() = %q, want %q", result, tt.expected)
+			}
+		})
+	}
+}
@@ -0,0 +1,132 @@
+package calendar
+
+import (
+	"fmt"
+	"slices"
+	"sort"
+)
+
+// eventKey identifies an event across fetches, so the cursor stays on it when
+// the events are refreshed.
+func eventKey(e CalendarEvent) string {
+	id := e.ICalUID
+	if id == "" {
+		id = e.ID
+	}
+	return fmt.Sprintf("%s/%s/%d", id, e.Title, e.StartTime.Unix())
+}
+
+// selectable returns the indexes into Events of the events the cursor can
+// move to, in the order tab walks them: by start time, then by column.
+func (m Model) selectable() []int {
+	end := m.StartDate.AddDate(0, 0, m.ColumnCount)
+	var indexes []int
+	for i, e := range m.Events {
+		if e.Hidden() || e.EventType == EventTypeWorkingLocation {
+			continue
+		}
+		if !e.EndTime.After(m.StartDate) || !e.StartTime.Before(end) {
+			continue
+		}
+		indexes = append(indexes, i)
+	}
+	sort.SliceStable(indexes, func(a, b int) bool {
+		return m.Events[indexes[a]].StartTime.Before(m.Events[indexes[b]].StartTime)
+	})
+	return indexes
+}
+
+// cursorEvent returns the event under the cursor, if it is still visible.
+func (m Model) cursorEvent() *CalendarEvent {
+	if m.Cursor == "" {
+		return nil
+	}
+	for _, i := range m.selectable() {
+		if eventKey(m.Events[i]) == m.Cursor {
+			return &m.Events[i]
+		}
+	}
+	return nil
+}
+
+// cursorDay returns the column the event is drawn in first.
+func (m Model) cursorDay(e CalendarEvent) int {
+	return max(dayIndex(m.StartDate, e.StartTime), 0)
+}
+
+// moveCursor moves the cursor by step through every visible event, wrapping
+// around at either end. Without a cursor it starts at the first or last one.
+func (m Model) moveCursor(step int) Model {
+	indexes := m.selectable()
+	keys := make([]string, len(indexes))
+	for p, i := range indexes {
+		keys[p] = eventKey(m.Events[i])
+	}
+	position := stepCursor(keys, m.Cursor, step, true)
+	if position < 0 {
+		return m
+	}
+	return m.selectEvent(m.Events[indexes[position]])
+}
+
+// moveCursorInDay moves the cursor by step among the events of the day it is
+// on, stopping at the first and last one. It reports false when there is no
+// cursor to move.
+func (m Model) moveCursorInDay(step int) (Model, bool) {
+	current := m.cursorEvent()
+	if current == nil {
+		return m, false
+	}
+
+	day := m.cursorDay(*current)
+	var sameDay []CalendarEvent
+	var keys []string
+	for _, i := range m.selectable() {
+		if e := m.Events[i]; m.cursorDay(e) == day {
+			sameDay = append(sameDay, e)
+			keys = append(keys, eventKey(e))
+		}
+	}
+	return m.selectEvent(sameDay[stepCursor(keys, m.Cursor, step, false)]), true
+}
+
+// stepCursor returns the position in keys that the cursor on the event with
+// key cursor moves to by step. With wrap it goes around at either end,
+// otherwise it stops there. A cursor not in keys starts at the first or last
+// one. It returns -1 when keys is empty.
+func stepCursor(keys []string, cursor string, step int, wrap bool) int {
+	if len(keys) == 0 {
+		return -1
+	}
+	position := slices.Index(keys, cursor)
+	switch {
+	case position < 0 && step > 0:
+		return 0
+	case position < 0:
+		return len(keys) - 1
+	case wrap:
+		return (position + step + len(keys)) % len(keys)
+	default:
+		return max(0, min(position+step, len(keys)-1))
+	}
+}
+
+// selectEvent puts the cursor on e and scrolls it into view.
+func (m Model) selectEvent(e CalendarEvent) Model {
+	m.Cursor = eventKey(e)
+	m.Scrolled = true // keep auto-scroll from moving the event out of view
+	if inBanner(e) {
+		return m
+	}
+
+	rows, _ := m.viewportRows()
+	start, _, ok := clipToDay(e, m.StartDate.AddDate(0, 0, m.cursorDay(e)))
+	if !ok {
+		return m
+	}
+	slot := m.slotAt(start)
+	if slot < m.Scroll || slot >= m.Scroll+rows {
+		m = m.scrollTo(slot - rows/3)
+	}
+	return m
+}
@@ -0,0 +1,110 @@
+package calendar
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	tea "charm.land/bubbletea/v2"
+	"github.com/kahnwong/gcal-tui/internal/utils"
+)
+
+func TestCursor(t *testing.T) {
+	original := utils.Location
+	utils.Location = time.UTC
+	defer func() { utils.Location = original }()
+
+	monday := time.Date(2026, 2, 2, 0, 0, 0, 0, time.UTC)
+	at := func(day, hour int) time.Time { return monday.AddDate(0, 0, day).Add(time.Duration(hour) * time.Hour) }
+	m := Model{
+		StartDate:   monday,
+		ColumnCount: 7,
+		ColWidth:    20,
+		Zones:       []*time.Location{time.UTC},
+		Now:         monday.AddDate(0, 0, 14),
+		Events: []CalendarEvent{
+			{ID: "c", Title: "Tuesday", StartTime: at(1, 9), EndTime: at(1, 10)},
+			{ID: "a", Title: "Standup", StartTime: at(0, 9), EndTime: at(0, 10)},
+			{ID: "b", Title: "Review", StartTime: at(0, 14), EndTime: at(0, 15)},
+			{ID: "h", Title: "Hidden", StartTime: at(0, 11), EndTime: at(0, 12), Display: "hide"},
+		},
+	}
+
+	press := func(key tea.KeyPressMsg) {
+		t.Helper()
+		updated, _ := m.Update(key)
+		m = updated.(Model)
+	}
+	title := func() string {
+		if e := m.cursorEvent(); e != nil {
+			return e.Title
+		}
+		return ""
+	}
+
+	steps := []struct {
+		key  tea.KeyPressMsg
+		want string
+	}{
+		{tea.KeyPressMsg{Code: tea.KeyTab}, "Standup"},
+		{tea.KeyPressMsg{Code: tea.KeyTab}, "Review"},
+		{tea.KeyPressMsg{Code: tea.KeyTab}, "Tuesday"},
+		{tea.KeyPressMsg{Code: tea.KeyTab}, "Standup"},
+		{tea.KeyPressMsg{Code: tea.KeyTab, Mod: tea.ModShift}, "Tuesday"},
+		{tea.KeyPressMsg{Code: tea.KeyUp}, "Tuesday"},
+		{tea.KeyPressMsg{Code: tea.KeyTab, Mod: tea.ModShift}, "Review"},
+		{tea.KeyPressMsg{Code: tea.KeyUp}, "Standup"},
+		{tea.KeyPressMsg{Code: tea.KeyDown}, "Review"},
+		{tea.KeyPressMsg{Code: tea.KeyDown}, "Review"},
+	}
+	for _, step := range steps {
+		press(step.key)
+		if got := title(); got != step.want {
+			t.Fatalf("After %s: cursor on %q, want %q", step.key, got, step.want)
+		}
+	}
+
+	if e := m.selectedEvent(); e == nil || e.Title != "Review" {
+		t.Errorf("Expected o to act on the event under the cursor, got %+v", e)
+	}
+
+	press(tea.KeyPressMsg{Code: tea.KeyEnter})
+	if !m.Detail || !strings.Contains(m.View().Content, "When") {
+		t.Error("Expected enter to open the detail pane")
+	}
+	press(tea.KeyPressMsg{Code: tea.KeyEscape})
+	if m.Detail || title() != "Review" {
+		t.Error("Expected esc to close the detail pane and keep the cursor")
+	}
+	press(tea.KeyPressMsg{Code: tea.KeyEscape})
+	if title() != "" {
+		t.Error("Expected a second esc to clear the cursor")
+	}
+}
+
+func TestStepCursor(t *testing.T) {
+	keys := []string{"a", "b", "c"}
+	tests := []struct {
+		cursor string
+		step   int
+		wrap   bool
+		want   int
+	}{
+		{"", 1, true, 0},
+		{"", -1, true, 2},
+		{"gone", 1, false, 0},
+		{"c", 1, true, 0},
+		{"a", -1, true, 2},
+		{"c", 1, false, 2},
+		{"a", -1, false, 0},
+		{"b", 1, false, 2},
+	}
+	for _, tt := range tests {
+		if got := stepCursor(keys, tt.cursor, tt.step, tt.wrap); got != tt.want {
+			t.Errorf("stepCursor(%q, %d, %v) = %d, want %d", tt.cursor, tt.step, tt.wrap, got, tt.want)
+		}
+	}
+	if got := stepCursor(nil, "a", 1, true); got != -1 {
+		t.Errorf("Expected -1 without keys, got %d", got)
+	}
+}
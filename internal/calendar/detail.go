@@ -0,0 +1,162 @@
+package calendar
+
+import (
+	"fmt"
+	"html"
+	"regexp"
+	"strings"
+	"time"
+
+	"charm.land/lipgloss/v2"
+)
+
+// Styles for the detail pane
+var (
+	DetailStyle      = lipgloss.NewStyle().Border(lipgloss.RoundedBorder()).BorderForeground(lipgloss.Color("#0FF")).Padding(0, 1)
+	DetailTitleStyle = lipgloss.NewStyle().Bold(true).Foreground(lipgloss.Color("#FFF"))
+	DetailLabelStyle = lipgloss.NewStyle().Foreground(lipgloss.Color("#0FF")).Width(11)
+)
+
+var (
+	htmlLink      = regexp.MustCompile(`(?is)<a\s[^>]*href="([^"]*)"[^>]*>(.*?)</a>`)
+	htmlBreak     = regexp.MustCompile(`(?i)<br\s*/?>|</p>|</div>|</li>|</h[1-6]>`)
+	htmlListItem  = regexp.MustCompile(`(?i)<li[^>]*>`)
+	htmlTag       = regexp.MustCompile(`<[^>]*>`)
+	blankLines    = regexp.MustCompile(`\n{3,}`)
+	trailingSpace = regexp.MustCompile(`[ \t]+\n`)
+)
+
+// plainText turns an event description, which Google stores as HTML, into
+// plain text: line breaks and list items are kept, links show their target,
+// and all other markup is dropped. Plain text and markdown pass unchanged.
+func plainText(description string) string {
+	text := htmlLink.ReplaceAllStringFunc(description, func(link string) string {
+		parts := htmlLink.FindStringSubmatch(link)
+		href, label := html.UnescapeString(parts[1]), htmlTag.ReplaceAllString(parts[2], "")
+		if label == "" || html.UnescapeString(label) == href {
+			return href
+		}
+		return fmt.Sprintf("%s (%s)", label, href)
+	})
+	text = htmlBreak.ReplaceAllString(text, "\n")
+	text = htmlListItem.ReplaceAllString(text, "• ")
+	text = htmlTag.ReplaceAllString(text, "")
+	text = html.UnescapeString(text)
+	text = trailingSpace.ReplaceAllString(text, "\n")
+	text = blankLines.ReplaceAllString(text, "\n\n")
+	return strings.TrimSpace(text)
+}
+
+// formatRange describes when an event takes place in loc, e.g.
+// "Mon 02 Feb 10:00–11:30 (1h30m)" or "Mon 02 Feb – Tue 03 Feb, all day".
+func formatRange(e CalendarEvent, loc *time.Location) string {
+	start := e.StartTime.In(loc)
+	end := e.EndTime.In(loc)
+	if e.AllDay {
+		last := end.AddDate(0, 0, -1) // the end of all-day events is exclusive
+		if dateDiff(start, last) <= 0 {
+			return start.Format("Mon 02 Jan") + ", all day"
+		}
+		return start.Format("Mon 02 Jan") + " – " + last.Format("Mon 02 Jan") + ", all day"
+	}
+
+	duration := formatDuration(end.Sub(start))
+	if dateDiff(start, end) == 0 {
+		return fmt.Sprintf("%s–%s (%s)", start.Format("Mon 02 Jan 15:04"), end.Format("15:04"), duration)
+	}
+	return fmt.Sprintf("%s – %s (%s)", start.Format("Mon 02 Jan 15:04"), end.Format("Mon 02 Jan 15:04"), duration)
+}
+
+// formatDuration returns d as e.g. "45m", "2h" or "1h30m".
+func formatDuration(d time.Duration) string {
+	hours, minutes := int(d.Hours()), int(d.Minutes())%60
+	switch {
+	case hours == 0:
+		return fmt.Sprintf("%dm", minutes)
+	case minutes == 0:
+		return fmt.Sprintf("%dh", hours)
+	default:
+		return fmt.Sprintf("%dh%02dm", hours, minutes)
+	}
+}
+
+// responseMarks shows an attendee's response next to their name.
+var responseMarks = map[string]string{
+	"accepted":    "✓",
+	"declined":    "✗",
+	"tentative":   "?",
+	"needsAction": "·",
+}
+
+// formatAttendee returns an attendee with their response, e.g.
+// "✓ Alice (organizer)".
+func formatAttendee(a Attendee) string {
+	mark, ok := responseMarks[a.ResponseStatus]
+	if !ok {
+		mark = "·"
+	}
+	var notes []string
+	if a.Organizer {
+		notes = append(notes, "organizer")
+	}
+	if a.Self {
+		notes = append(notes, "you")
+	}
+	if a.Optional {
+		notes = append(notes, "optional")
+	}
+	line := mark + " " + a.Name()
+	if len(notes) > 0 {
+		line += " (" + strings.Join(notes, ", ") + ")"
+	}
+	return line
+}
+
+// renderDetail draws the detail pane for e, wrapped to width, with its times
+// in loc.
+func renderDetail(e CalendarEvent, width int, loc *time.Location) string {
+	inner := max(width-DetailStyle.GetHorizontalFrameSize(), 20)
+	text := lipgloss.NewStyle().Width(inner - DetailLabelStyle.GetWidth())
+
+	field := func(label, value string) string {
+		return lipgloss.JoinHorizontal(lipgloss.Top, DetailLabelStyle.Render(label), text.Render(value))
+	}
+
+	lines := []string{DetailTitleStyle.Width(inner).Render(e.DisplayTitle()), ""}
+	lines = append(lines, field("When", formatRange(e, loc)))
+
+	source := e.SourceName()
+	if e.AccountName != "" {
+		source = e.AccountName + " / " + source
+	}
+	lines = append(lines, field("Calendar", source))
+	if e.Status != "" && e.Status != "confirmed" {
+		lines = append(lines, field("Status", e.Status))
+	}
+	if e.Location != "" {
+		lines = append(lines, field("Location", e.Location))
+	}
+	if e.JoinURL != "" {
+		lines = append(lines, field("Join", e.JoinURL))
+	}
+
+	if len(e.Attendees) > 0 {
+		var attendees []string
+		for _, a := range e.Attendees {
+			attendees = append(attendees, formatAttendee(a))
+		}
+		lines = append(lines, field("Attendees", strings.Join(attendees, "\n")))
+	} else if e.Organizer.Email != "" {
+		lines = append(lines, field("Organizer", e.Organizer.Name()))
+	}
+
+	if description := plainText(e.Description); description != "" {
+		lines = append(lines, "", lipgloss.NewStyle().Width(inner).Render(description))
+	}
+	if e.HTMLLink != "" {
+		lines = append(lines, "", SecondaryTimeLabelStyle.Width(inner).Render(e.HTMLLink))
+	}
+
+	lines = append(lines, "", SecondaryTimeLabelStyle.Render("enter/esc: Close   j: Join"))
+	return DetailStyle.Width(width).Render(strings.Join(lines, "\n"))
+}
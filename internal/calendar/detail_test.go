@@ -0,0 +1,85 @@
+package calendar
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"charm.land/lipgloss/v2"
+	"github.com/kahnwong/gcal-tui/internal/utils"
+)
+
+func TestPlainText(t *testing.T) {
+	tests := []struct {
+		name        string
+		description string
+		expected    string
+	}{
+		{"plain", "Agenda:\n- budget", "Agenda:\n- budget"},
+		{"line breaks", "Hello<br>world<br/>again", "Hello\nworld\nagain"},
+		{"list", "<ul><li>one</li><li>two</li></ul>", "• one\n• two"},
+		{"link", `See <a href="https://example.com/doc">the doc</a>`, "See the doc (https://example.com/doc)"},
+		{"bare link", `<a href="https://example.com">https://example.com</a>`, "https://example.com"},
+		{"entities", "<b>Tom &amp; Jerry</b> &lt;3", "Tom & Jerry <3"},
+		{"blank lines", "<p>one</p><p></p><p></p><p>two</p>", "one\n\ntwo"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if result := plainText(tt.description); result != tt.expected {
+				t.Errorf("plainText() = %q, want %q", result, tt.expected)
+			}
+		})
+	}
+}
+
+func TestFormatRange(t *testing.T) {
+	original := utils.Location
+	utils.Location = time.UTC
+	defer func() { utils.Location = original }()
+
+	monday := time.Date(2026, 2, 2, 0, 0, 0, 0, time.UTC)
+	tests := []struct {
+		name     string
+		event    CalendarEvent
+		expected string
+	}{
+		{"timed", CalendarEvent{StartTime: monday.Add(10 * time.Hour), EndTime: monday.Add(11*time.Hour + 30*time.Minute)}, "Mon 02 Feb 10:00–11:30 (1h30m)"},
+		{"overnight", CalendarEvent{StartTime: monday.Add(22 * time.Hour), EndTime: monday.Add(26 * time.Hour)}, "Mon 02 Feb 22:00 – Tue 03 Feb 02:00 (4h)"},
+		{"all day", CalendarEvent{StartTime: monday, EndTime: monday.AddDate(0, 0, 1), AllDay: true}, "Mon 02 Feb, all day"},
+		{"several days", CalendarEvent{StartTime: monday, EndTime: monday.AddDate(0, 0, 3), AllDay: true}, "Mon 02 Feb – Wed 04 Feb, all day"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if result := formatRange(tt.event, utils.Location); result != tt.expected {
+				t.Errorf("formatRange() = %q, want %q", result, tt.expected)
+			}
+		})
+	}
+}
+
+func TestRenderDetail(t *testing.T) {
+	e := CalendarEvent{
+		Title:        "Planning",
+		StartTime:    time.Date(2026, 2, 2, 10, 0, 0, 0, time.UTC),
+		EndTime:      time.Date(2026, 2, 2, 11, 0, 0, 0, time.UTC),
+		AccountName:  "work",
+		CalendarName: "Team",
+		Location:     "Room 4",
+		JoinURL:      "https://meet.google.com/abc-defg-hij",
+		Description:  "Bring <b>numbers</b>",
+		Attendees: []Attendee{
+			{Email: "alice@example.com", DisplayName: "Alice", ResponseStatus: "accepted", Organizer: true},
+			{Email: "bob@example.com", ResponseStatus: "declined"},
+		},
+	}
+
+	result := renderDetail(e, 60, utils.Location)
+	for _, want := range []string{"Planning", "work / Team", "Room 4", "https://meet.google.com/abc-defg-hij", "✓ Alice (organizer)", "✗ bob@example.com", "Bring numbers"} {
+		if !strings.Contains(result, want) {
+			t.Errorf("Expected %q in the detail pane, got:\n%s", want, result)
+		}
+	}
+	if width := lipgloss.Width(result); width > 60 {
+		t.Errorf("Expected the detail pane to fit in 60 columns, got %d", width)
+	}
+}
@@ -0,0 +1,394 @@
+package calendar
+
+import (
+	"errors"
+	"fmt"
+	"log/slog"
+	"net/http"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	cliBase "github.com/kahnwong/cli-base"
+	"github.com/kahnwong/gcal-tui/configs"
+	"github.com/kahnwong/gcal-tui/internal/gcal"
+	"github.com/kahnwong/gcal-tui/internal/utils"
+	"google.golang.org/api/calendar/v3"
+)
+
+// Source is where the events of one account come from.
+type Source interface {
+	Colors() (*calendar.Colors, error)
+	CalendarListEntry(calendarId string) (*calendar.CalendarListEntry, error)
+	// Events returns the events overlapping [start, end).
+	Events(start, end time.Time, calendarId string, showDeleted bool) (*calendar.Events, error)
+}
+
+// gcalSource reads from the Google Calendar API with an authorized client.
+type gcalSource struct {
+	client *http.Client
+}
+
+func (s gcalSource) Colors() (*calendar.Colors, error) {
+	return gcal.GetColors(s.client)
+}
+
+func (s gcalSource) CalendarListEntry(calendarId string) (*calendar.CalendarListEntry, error) {
+	return gcal.GetCalendarListEntry(calendarId, s.client)
+}
+
+func (s gcalSource) Events(start, end time.Time, calendarId string, showDeleted bool) (*calendar.Events, error) {
+	return gcal.GetEvents(start, end, calendarId, showDeleted, s.client)
+}
+
+// connectAccount authorizes an account against the Google Calendar API.
+func connectAccount(account configs.Account) (Source, error) {
+	expandedPath, err := cliBase.ExpandHome(account.Credentials)
+	if err != nil {
+		return nil, fmt.Errorf("failed to expand home path for account '%s': %w", account.Name, err)
+	}
+	oathClientIDJson, err := gcal.ReadOauthClientID(expandedPath)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read OAuth client ID for account '%s': %w", account.Name, err)
+	}
+	client, err := gcal.GetClient(account.Name, oathClientIDJson)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get client for account '%s': %w", account.Name, err)
+	}
+	return gcalSource{client: client}, nil
+}
+
+// sourceCache holds the connected source of each account, or the error
+// connecting it, for the whole session. Failures are kept rather than retried,
+// since retrying could prompt for an authorization code while a TUI owns the
+// terminal.
+type sourceCache struct {
+	mu          sync.Mutex
+	connections map[string]*connection // by account name
+}
+
+// connection is the outcome of connecting one account. once makes concurrent
+// fetches wait for a single connect, so a token about to expire is refreshed
+// and saved to its file once.
+type connection struct {
+	once   sync.Once
+	source Source
+	err    error
+}
+
+func newSourceCache() *sourceCache {
+	return &sourceCache{connections: map[string]*connection{}}
+}
+
+// source returns the source of account, calling connect on first use.
+func (c *sourceCache) source(account configs.Account, connect func(configs.Account) (Source, error)) (Source, error) {
+	c.mu.Lock()
+	conn, ok := c.connections[account.Name]
+	if !ok {
+		conn = &connection{}
+		c.connections[account.Name] = conn
+	}
+	c.mu.Unlock()
+
+	conn.once.Do(func() {
+		conn.source, conn.err = connect(account)
+	})
+	return conn.source, conn.err
+}
+
+// sessionSources is shared by all fetches of the session, see Connect.
+var sessionSources = newSourceCache()
+
+// Connect authorizes every configured account, so fetches reuse the clients
+// instead of connecting again. Authorizing an account for the first time, or
+// after its refresh token was rejected, asks for a code on stdin, so this has
+// to run before a TUI takes over the terminal. Accounts that fail are reported
+// by every fetch, see FetchError.
+func Connect() {
+	for _, account := range configs.AppConfig.Accounts {
+		_, _ = sessionSources.source(account, connectAccount)
+	}
+}
+
+// sessionSource returns the source Connect set up for account.
+func sessionSource(account configs.Account) (Source, error) {
+	return sessionSources.source(account, connectAccount)
+}
+
+// FetchError records a failure to fetch events for one account, or for one
+// calendar of it when Calendar is set.
+type FetchError struct {
+	Account  string
+	Calendar string
+	Err      error
+}
+
+func (e *FetchError) Error() string {
+	return e.Err.Error()
+}
+
+func (e *FetchError) Unwrap() error {
+	return e.Err
+}
+
+// Source names what failed, e.g. "work/primary" or "personal" for an account.
+func (e *FetchError) Source() string {
+	if e.Calendar == "" {
+		return e.Account
+	}
+	return e.Account + "/" + e.Calendar
+}
+
+// FetchErrors returns the per-account and per-calendar failures in err, as
+// returned by FetchAllEvents.
+func FetchErrors(err error) []*FetchError {
+	var fetchErrors []*FetchError
+	if joined, ok := err.(interface{ Unwrap() []error }); ok {
+		for _, e := range joined.Unwrap() {
+			fetchErrors = append(fetchErrors, FetchErrors(e)...)
+		}
+		return fetchErrors
+	}
+
+	var fetchErr *FetchError
+	if errors.As(err, &fetchErr) {
+		fetchErrors = append(fetchErrors, fetchErr)
+	}
+	return fetchErrors
+}
+
+// FetchWarning summarizes a FetchAllEvents error for display, naming the
+// calendars that could not be loaded. It is empty when err is nil.
+func FetchWarning(err error) string {
+	if err == nil {
+		return ""
+	}
+
+	var sources []string
+	for _, fetchErr := range FetchErrors(err) {
+		sources = append(sources, fetchErr.Source())
+	}
+	if len(sources) == 0 {
+		return "⚠ Failed to load events: " + err.Error()
+	}
+	return "⚠ Failed to load: " + strings.Join(sources, ", ")
+}
+
+var (
+	limiter     *utils.Limiter
+	limiterOnce sync.Once
+)
+
+// fetchConfig returns the configured fetch limits, with defaults filled in.
+func fetchConfig() configs.FetchConfig {
+	var config configs.FetchConfig
+	if configs.AppConfig != nil {
+		config = configs.AppConfig.Fetch
+	}
+	if config.Workers <= 0 {
+		config.Workers = configs.DefaultFetchConfig.Workers
+	}
+	if config.RequestsPerSecond <= 0 {
+		config.RequestsPerSecond = configs.DefaultFetchConfig.RequestsPerSecond
+	}
+	if config.Burst <= 0 {
+		config.Burst = configs.DefaultFetchConfig.Burst
+	}
+	return config
+}
+
+// sharedLimiter is the rate limiter for all API requests, so that fetches of
+// different accounts and periods together stay within Google's per-user quota.
+func sharedLimiter() *utils.Limiter {
+	limiterOnce.Do(func() {
+		config := fetchConfig()
+		limiter = utils.NewLimiter(config.RequestsPerSecond, config.Burst)
+	})
+	return limiter
+}
+
+// metadataCache holds the color palette of each account and the list entry of
+// each calendar. They rarely change, so they are fetched once per session and
+// every load after the first only fetches events. Failed lookups are not
+// cached, so they are retried on the next load.
+type metadataCache struct {
+	mu       sync.Mutex
+	palettes map[string]*calendar.Colors            // by account name
+	entries  map[string]*calendar.CalendarListEntry // by account name and calendar id
+}
+
+func newMetadataCache() *metadataCache {
+	return &metadataCache{
+		palettes: map[string]*calendar.Colors{},
+		entries:  map[string]*calendar.CalendarListEntry{},
+	}
+}
+
+// palette returns the color palette of account, calling fetch on first use.
+func (c *metadataCache) palette(account string, fetch func() (*calendar.Colors, error)) (*calendar.Colors, error) {
+	c.mu.Lock()
+	palette, ok := c.palettes[account]
+	c.mu.Unlock()
+	if ok {
+		return palette, nil
+	}
+
+	palette, err := fetch()
+	if err != nil {
+		return nil, err
+	}
+	c.mu.Lock()
+	c.palettes[account] = palette
+	c.mu.Unlock()
+	return palette, nil
+}
+
+// entry returns the calendar list entry of a calendar, calling fetch on first
+// use.
+func (c *metadataCache) entry(account, calendarId string, fetch func() (*calendar.CalendarListEntry, error)) (*calendar.CalendarListEntry, error) {
+	key := account + "/" + calendarId
+	c.mu.Lock()
+	entry, ok := c.entries[key]
+	c.mu.Unlock()
+	if ok {
+		return entry, nil
+	}
+
+	entry, err := fetch()
+	if err != nil {
+		return nil, err
+	}
+	c.mu.Lock()
+	c.entries[key] = entry
+	c.mu.Unlock()
+	return entry, nil
+}
+
+// sessionMetadata is shared by all fetches of the session.
+var sessionMetadata = newMetadataCache()
+
+// FetchAllEvents fetches the events overlapping [start, end) from every
+// configured calendar. Calendars that fail are skipped and reported in the
+// returned error, which joins one *FetchError per failure, so the events from
+// the others are still usable.
+func FetchAllEvents(start, end time.Time) ([]CalendarEvent, error) {
+	allEvents, err := fetchEvents(configs.AppConfig.Accounts, start, end, sessionSource, fetchConfig().Workers, sharedLimiter(), sessionMetadata)
+
+	// results arrive in random order, sort them so de-duplication is stable
+	rank := configRank(configs.AppConfig)
+	sort.SliceStable(allEvents, func(i, j int) bool {
+		return rank(allEvents[i]) < rank(allEvents[j])
+	})
+	allEvents = DedupeEvents(allEvents, rank)
+
+	return allEvents, err
+}
+
+// waiter is satisfied by *utils.Limiter.
+type waiter interface {
+	Wait()
+}
+
+// fetchJob is one calendar to fetch.
+type fetchJob struct {
+	account  configs.Account
+	calendar configs.Calendar
+	source   Source
+	palette  *calendar.Colors
+	metadata *metadataCache
+}
+
+// fetchEvents fetches the calendars of all accounts with at most workers
+// requests in flight, each waiting on limiter first. Palettes and calendar
+// list entries are looked up in metadata.
+func fetchEvents(accounts []configs.Account, start, end time.Time, connect func(configs.Account) (Source, error), workers int, limiter waiter, metadata *metadataCache) ([]CalendarEvent, error) {
+	var fetchErrors []error
+
+	// Accounts are connected one at a time, since connecting one that Connect
+	// has not seen may prompt for an authorization code on stdin. Concurrent
+	// fetches share the connections, see sourceCache.
+	var jobs []fetchJob
+	for _, account := range accounts {
+		source, err := connect(account)
+		if err != nil {
+			fetchErrors = append(fetchErrors, &FetchError{Account: account.Name, Err: err})
+			continue
+		}
+
+		// colors are cosmetic, so fall back to config/defaults instead of failing
+		palette, err := metadata.palette(account.Name, func() (*calendar.Colors, error) {
+			limiter.Wait()
+			return source.Colors()
+		})
+		if err != nil {
+			slog.Warn("failed to get color palette", "account", account.Name, "error", err)
+		}
+
+		for _, calendarInfo := range account.Calendars {
+			jobs = append(jobs, fetchJob{account: account, calendar: calendarInfo, source: source, palette: palette, metadata: metadata})
+		}
+	}
+
+	jobsCh := make(chan fetchJob)
+	var mu sync.Mutex
+	var allEvents []CalendarEvent
+	var wg sync.WaitGroup
+	for range max(workers, 1) {
+		wg.Go(func() {
+			for job := range jobsCh {
+				events, err := fetchCalendar(job, start, end, limiter)
+				mu.Lock()
+				if err != nil {
+					fetchErrors = append(fetchErrors, err)
+				} else {
+					allEvents = append(allEvents, events...)
+				}
+				mu.Unlock()
+			}
+		})
+	}
+	for _, job := range jobs {
+		jobsCh <- job
+	}
+	close(jobsCh)
+	wg.Wait()
+
+	// failed calendars are reported alongside whatever could be fetched
+	return allEvents, errors.Join(fetchErrors...)
+}
+
+// fetchCalendar fetches and parses the events of a single calendar.
+func fetchCalendar(job fetchJob, start, end time.Time, limiter waiter) ([]CalendarEvent, error) {
+	account, calInfo := job.account, job.calendar
+
+	filter := ResolveFilter(calInfo.Filter)
+	// cancelled instances are only returned when asked for
+	limiter.Wait()
+	events, err := job.source.Events(start, end, calInfo.Id, filter.Cancelled != configs.FilterHide)
+	if err != nil {
+		return nil, &FetchError{Account: account.Name, Calendar: calInfo.Id, Err: fmt.Errorf("failed to get events for calendar '%s': %w", calInfo.Id, err)}
+	}
+
+	var entry *calendar.CalendarListEntry
+	if calInfo.Color == "" {
+		entry, err = job.metadata.entry(account.Name, calInfo.Id, func() (*calendar.CalendarListEntry, error) {
+			limiter.Wait()
+			return job.source.CalendarListEntry(calInfo.Id)
+		})
+		if err != nil {
+			slog.Warn("failed to get calendar colors", "calendar", calInfo.Id, "error", err)
+		}
+	}
+
+	calendarEvents, err := ParseCalendars(ResolveCalendarColors(calInfo.Color, entry, job.palette), events)
+	if err != nil {
+		return nil, &FetchError{Account: account.Name, Calendar: calInfo.Id, Err: fmt.Errorf("failed to parse calendars for calendar '%s': %w", calInfo.Id, err)}
+	}
+	for i := range calendarEvents {
+		calendarEvents[i].CalendarID = calInfo.Id
+		calendarEvents[i].AccountName = account.Name
+	}
+	ApplyFilter(calendarEvents, filter)
+	return calendarEvents, nil
+}
@@ -0,0 +1,284 @@
+package calendar
+
+import (
+	"errors"
+	"fmt"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/kahnwong/gcal-tui/configs"
+	"github.com/kahnwong/gcal-tui/internal/utils"
+	"google.golang.org/api/calendar/v3"
+)
+
+func TestFetchErrors(t *testing.T) {
+	accountErr := &FetchError{Account: "personal", Err: errors.New("failed to get client for account 'personal'")}
+	calendarErr := &FetchError{Account: "work", Calendar: "team@group.calendar.google.com", Err: errors.New("failed to get events")}
+	err := errors.Join(accountErr, calendarErr)
+
+	fetchErrors := FetchErrors(err)
+	if len(fetchErrors) != 2 || fetchErrors[0] != accountErr || fetchErrors[1] != calendarErr {
+		t.Fatalf("Expected both failures, got %v", fetchErrors)
+	}
+	if !errors.Is(err, calendarErr) {
+		t.Error("Expected the joined error to match each failure")
+	}
+
+	warning := FetchWarning(err)
+	if warning != "⚠ Failed to load: personal, work/team@group.calendar.google.com" {
+		t.Errorf("Unexpected warning %q", warning)
+	}
+	if FetchWarning(nil) != "" {
+		t.Error("Expected no warning without an error")
+	}
+}
+
+// fakeSource serves one event per calendar and tracks concurrent requests.
+type fakeSource struct {
+	delay    time.Duration
+	failing  map[string]bool
+	inFlight atomic.Int32
+	maxSeen  atomic.Int32
+	requests atomic.Int32
+}
+
+func (s *fakeSource) begin() func() {
+	s.requests.Add(1)
+	current := s.inFlight.Add(1)
+	for {
+		seen := s.maxSeen.Load()
+		if current <= seen || s.maxSeen.CompareAndSwap(seen, current) {
+			break
+		}
+	}
+	time.Sleep(s.delay)
+	return func() { s.inFlight.Add(-1) }
+}
+
+func (s *fakeSource) Colors() (*calendar.Colors, error) {
+	defer s.begin()()
+	return &calendar.Colors{}, nil
+}
+
+func (s *fakeSource) CalendarListEntry(calendarId string) (*calendar.CalendarListEntry, error) {
+	defer s.begin()()
+	return &calendar.CalendarListEntry{Id: calendarId, BackgroundColor: "#9fe1e7"}, nil
+}
+
+func (s *fakeSource) Events(weekStart, _ time.Time, calendarId string, showDeleted bool) (*calendar.Events, error) {
+	defer s.begin()()
+	if s.failing[calendarId] {
+		return nil, errors.New("rate limit exceeded")
+	}
+	start := weekStart.Add(10 * time.Hour)
+	return &calendar.Events{
+		Summary: calendarId,
+		Items: []*calendar.Event{{
+			Id:      calendarId + "-event",
+			Summary: "Event in " + calendarId,
+			Start:   &calendar.EventDateTime{DateTime: start.Format(time.RFC3339)},
+			End:     &calendar.EventDateTime{DateTime: start.Add(time.Hour).Format(time.RFC3339)},
+		}},
+	}, nil
+}
+
+// fakeAccounts returns accounts with calendarsPerAccount calendars each.
+func fakeAccounts(accounts, calendarsPerAccount int) []configs.Account {
+	var result []configs.Account
+	for a := range accounts {
+		account := configs.Account{Name: fmt.Sprintf("account-%d", a)}
+		for c := range calendarsPerAccount {
+			account.Calendars = append(account.Calendars, configs.Calendar{Id: fmt.Sprintf("cal-%d-%d", a, c)})
+		}
+		result = append(result, account)
+	}
+	return result
+}
+
+func TestFetchEventsBoundsConcurrency(t *testing.T) {
+	source := &fakeSource{
+		delay:   time.Millisecond,
+		failing: map[string]bool{"cal-1-7": true},
+	}
+	accounts := append(fakeAccounts(3, 100), configs.Account{Name: "broken"})
+	connect := func(account configs.Account) (Source, error) {
+		if account.Name == "broken" {
+			return nil, errors.New("token expired")
+		}
+		return source, nil
+	}
+
+	events, err := fetchEvents(accounts, time.Date(2026, 2, 2, 0, 0, 0, 0, time.UTC), time.Date(2026, 2, 9, 0, 0, 0, 0, time.UTC), connect, 4, (*utils.Limiter)(nil), newMetadataCache())
+
+	if len(events) != 299 {
+		t.Errorf("Expected 299 events, got %d", len(events))
+	}
+	if max := source.maxSeen.Load(); max > 4 {
+		t.Errorf("Expected at most 4 requests in flight, saw %d", max)
+	}
+
+	fetchErrors := FetchErrors(err)
+	if len(fetchErrors) != 2 {
+		t.Fatalf("Expected 2 failures, got %v", fetchErrors)
+	}
+	sources := map[string]bool{}
+	for _, fetchErr := range fetchErrors {
+		sources[fetchErr.Source()] = true
+	}
+	if !sources["broken"] || !sources["account-1/cal-1-7"] {
+		t.Errorf("Unexpected failures %v", sources)
+	}
+
+	for _, e := range events {
+		if e.AccountName == "" || e.CalendarID == "" || e.Color != "#9fe1e7" {
+			t.Fatalf("Expected source and color to be set, got %+v", e)
+		}
+	}
+}
+
+func TestFetchEventsSharesLimiter(t *testing.T) {
+	source := &fakeSource{}
+	connect := func(configs.Account) (Source, error) { return source, nil }
+
+	limiter := &countingLimiter{}
+	if _, err := fetchEvents(fakeAccounts(2, 5), time.Now(), time.Now().AddDate(0, 0, 7), connect, 3, limiter, newMetadataCache()); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if requests := source.requests.Load(); limiter.waits.Load() != requests {
+		t.Errorf("Expected every one of %d requests to wait on the limiter, got %d waits", requests, limiter.waits.Load())
+	}
+}
+
+func TestMetadataCache(t *testing.T) {
+	cache := newMetadataCache()
+	calls := 0
+	fetchPalette := func() (*calendar.Colors, error) {
+		calls++
+		return &calendar.Colors{}, nil
+	}
+	for range 3 {
+		if _, err := cache.palette("work", fetchPalette); err != nil {
+			t.Fatalf("Unexpected error: %v", err)
+		}
+	}
+	if calls != 1 {
+		t.Errorf("Expected the palette to be fetched once, got %d", calls)
+	}
+
+	failing := func() (*calendar.CalendarListEntry, error) {
+		calls++
+		return nil, errors.New("rate limit exceeded")
+	}
+	fetchEntry := func() (*calendar.CalendarListEntry, error) {
+		calls++
+		return &calendar.CalendarListEntry{BackgroundColor: "#9fe1e7"}, nil
+	}
+	calls = 0
+	if _, err := cache.entry("work", "primary", failing); err == nil {
+		t.Fatal("Expected the error to be returned")
+	}
+	for range 2 {
+		if entry, err := cache.entry("work", "primary", fetchEntry); err != nil || entry.BackgroundColor != "#9fe1e7" {
+			t.Fatalf("entry() = (%+v, %v)", entry, err)
+		}
+	}
+	if _, err := cache.entry("personal", "primary", fetchEntry); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if calls != 3 {
+		t.Errorf("Expected a failed lookup to be retried and each calendar fetched once, got %d calls", calls)
+	}
+}
+
+func TestSourceCache(t *testing.T) {
+	cache := newSourceCache()
+	connects := map[string]int{}
+	connect := func(account configs.Account) (Source, error) {
+		connects[account.Name]++
+		if account.Name == "broken" {
+			return nil, errors.New("token revoked")
+		}
+		return &fakeSource{}, nil
+	}
+
+	for range 3 {
+		for _, account := range []configs.Account{{Name: "work"}, {Name: "broken"}} {
+			source, err := cache.source(account, connect)
+			if (account.Name == "broken") != (err != nil) || (err == nil) != (source != nil) {
+				t.Fatalf("source(%s) = (%v, %v)", account.Name, source, err)
+			}
+		}
+	}
+	if connects["work"] != 1 || connects["broken"] != 1 {
+		t.Errorf("Expected each account to be connected once, got %v", connects)
+	}
+}
+
+func TestSourceCacheConnectsOnceAcrossFetches(t *testing.T) {
+	cache := newSourceCache()
+	var connects atomic.Int32
+	connect := func(account configs.Account) (Source, error) {
+		connects.Add(1)
+		time.Sleep(time.Millisecond) // refreshing a token takes a while
+		return &fakeSource{}, nil
+	}
+
+	// a load and its two prefetches run at the same time
+	var wg sync.WaitGroup
+	for range 3 {
+		wg.Go(func() {
+			connect := func(account configs.Account) (Source, error) { return cache.source(account, connect) }
+			if _, err := fetchEvents(fakeAccounts(2, 1), time.Now(), time.Now().AddDate(0, 0, 7), connect, 2, (*utils.Limiter)(nil), newMetadataCache()); err != nil {
+				t.Errorf("Unexpected error: %v", err)
+			}
+		})
+	}
+	wg.Wait()
+
+	if got := connects.Load(); got != 2 {
+		t.Errorf("Expected each of 2 accounts to be connected once, got %d connects", got)
+	}
+}
+
+func TestFetchEventsCachesMetadata(t *testing.T) {
+	source := &fakeSource{}
+	connect := func(configs.Account) (Source, error) { return source, nil }
+	monday := time.Date(2026, 2, 2, 0, 0, 0, 0, time.UTC)
+	metadata := newMetadataCache()
+
+	for week := range 3 {
+		start := monday.AddDate(0, 0, 7*week)
+		if _, err := fetchEvents(fakeAccounts(2, 5), start, start.AddDate(0, 0, 7), connect, 3, (*utils.Limiter)(nil), metadata); err != nil {
+			t.Fatalf("Unexpected error: %v", err)
+		}
+	}
+
+	// 2 palettes and 10 list entries once, then 10 event lists per week
+	if got, want := source.requests.Load(), int32(2+10+3*10); got != want {
+		t.Errorf("Expected %d requests, got %d", want, got)
+	}
+}
+
+// countingLimiter counts waits without limiting.
+type countingLimiter struct {
+	waits atomic.Int32
+}
+
+func (l *countingLimiter) Wait() {
+	l.waits.Add(1)
+}
+
+func BenchmarkFetchEvents(b *testing.B) {
+	source := &fakeSource{delay: 100 * time.Microsecond}
+	connect := func(configs.Account) (Source, error) { return source, nil }
+	accounts := fakeAccounts(5, 100)
+	weekStart := time.Date(2026, 2, 2, 0, 0, 0, 0, time.UTC)
+
+	for b.Loop() {
+		if _, err := fetchEvents(accounts, weekStart, weekStart.AddDate(0, 0, 7), connect, 8, (*utils.Limiter)(nil), newMetadataCache()); err != nil {
+			b.Fatal(err)
+		}
+	}
+}
@@ -0,0 +1,66 @@
+package calendar
+
+import (
+	"github.com/kahnwong/gcal-tui/configs"
+)
+
+// FilterOverride holds filter modes set from the command line, which take
+// precedence over both the per-calendar and the global config.
+var FilterOverride configs.EventFilter
+
+// ResolveFilter returns the effective filter for a calendar.
+func ResolveFilter(calendarFilter configs.EventFilter) configs.EventFilter {
+	filter := FilterOverride.Merge(calendarFilter)
+	if configs.AppConfig != nil {
+		filter = filter.Merge(configs.AppConfig.Filter)
+	}
+	return filter.Merge(configs.DefaultEventFilter)
+}
+
+// ApplyFilter sets Display on each event according to filter. When several
+// rules match, hiding wins over dimming.
+func ApplyFilter(events []CalendarEvent, filter configs.EventFilter) {
+	for i, e := range events {
+		var modes []string
+		if e.Declined() {
+			modes = append(modes, filter.Declined)
+		}
+		if e.Status == "cancelled" {
+			modes = append(modes, filter.Cancelled)
+		}
+		if e.Transparency == "transparent" {
+			modes = append(modes, filter.Free)
+		}
+
+		events[i].Display = configs.FilterShow
+		for _, mode := range modes {
+			if mode == configs.FilterHide {
+				events[i].Display = configs.FilterHide
+				break
+			}
+			if mode == configs.FilterDim {
+				events[i].Display = configs.FilterDim
+			}
+		}
+	}
+}
+
+// Declined reports whether the calendar owner declined the event.
+func (e CalendarEvent) Declined() bool {
+	for _, attendee := range e.Attendees {
+		if attendee.Self {
+			return attendee.ResponseStatus == "declined"
+		}
+	}
+	return false
+}
+
+// Hidden reports whether the event is filtered out entirely.
+func (e CalendarEvent) Hidden() bool {
+	return e.Display == configs.FilterHide
+}
+
+// Dimmed reports whether the event is shown but de-emphasized.
+func (e CalendarEvent) Dimmed() bool {
+	return e.Display == configs.FilterDim
+}
@@ -0,0 +1,56 @@
+package calendar
+
+import (
+	"testing"
+
+	"github.com/kahnwong/gcal-tui/configs"
+)
+
+func TestApplyFilter(t *testing.T) {
+	declined := CalendarEvent{Title: "Declined", Attendees: []Attendee{{Email: "me@example.com", Self: true, ResponseStatus: "declined"}}}
+	accepted := CalendarEvent{Title: "Accepted", Attendees: []Attendee{{Email: "me@example.com", Self: true, ResponseStatus: "accepted"}, {Email: "other@example.com", ResponseStatus: "declined"}}}
+	cancelled := CalendarEvent{Title: "Cancelled", Status: "cancelled"}
+	free := CalendarEvent{Title: "Free", Transparency: "transparent"}
+	freeDeclined := CalendarEvent{Title: "Free and declined", Transparency: "transparent", Attendees: declined.Attendees}
+
+	tests := []struct {
+		name     string
+		filter   configs.EventFilter
+		event    CalendarEvent
+		expected string
+	}{
+		{"declined is dimmed by default", configs.DefaultEventFilter, declined, configs.FilterDim},
+		{"someone else declining does not count", configs.DefaultEventFilter, accepted, configs.FilterShow},
+		{"cancelled is hidden by default", configs.DefaultEventFilter, cancelled, configs.FilterHide},
+		{"free is shown by default", configs.DefaultEventFilter, free, configs.FilterShow},
+		{"free can be hidden", configs.EventFilter{Declined: configs.FilterShow, Cancelled: configs.FilterShow, Free: configs.FilterHide}, free, configs.FilterHide},
+		{"hide wins over dim", configs.EventFilter{Declined: configs.FilterDim, Free: configs.FilterHide}, freeDeclined, configs.FilterHide},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			events := []CalendarEvent{tt.event}
+			ApplyFilter(events, tt.filter)
+			if events[0].Display != tt.expected {
+				t.Errorf("Display = %q, want %q", events[0].Display, tt.expected)
+			}
+		})
+	}
+}
+
+func TestResolveFilter(t *testing.T) {
+	t.Cleanup(func() { FilterOverride = configs.EventFilter{} })
+
+	calendarFilter := configs.EventFilter{Declined: configs.FilterHide}
+	filter := ResolveFilter(calendarFilter)
+	if filter.Declined != configs.FilterHide {
+		t.Errorf("Expected per-calendar declined mode, got %q", filter.Declined)
+	}
+	if filter.Cancelled != configs.DefaultEventFilter.Cancelled || filter.Free != configs.DefaultEventFilter.Free {
+		t.Errorf("Expected defaults for unset modes, got %+v", filter)
+	}
+
+	FilterOverride = configs.EventFilter{Declined: configs.FilterShow}
+	if filter := ResolveFilter(calendarFilter); filter.Declined != configs.FilterShow {
+		t.Errorf("Expected flag override to win, got %q", filter.Declined)
+	}
+}
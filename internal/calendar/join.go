@@ -0,0 +1,123 @@
+package calendar
+
+import (
+	"fmt"
+	"net/url"
+	"os"
+	"os/exec"
+	"regexp"
+	"runtime"
+	"strings"
+
+	tea "charm.land/bubbletea/v2"
+)
+
+// conferenceHosts are the domains recognized as meeting links, matched
+// against the host and any of its parent domains.
+var conferenceHosts = []string{
+	"meet.google.com",
+	"zoom.us",
+	"teams.microsoft.com",
+	"teams.live.com",
+	"webex.com",
+}
+
+var urlPattern = regexp.MustCompile(`https?://[^\s"'<>]+`)
+
+// FindConferenceURL returns the first Meet, Zoom, Teams or Webex link in text.
+func FindConferenceURL(text string) string {
+	for _, candidate := range urlPattern.FindAllString(text, -1) {
+		candidate = strings.TrimRight(candidate, ".,;:)]}")
+		if isConferenceURL(candidate) {
+			return candidate
+		}
+	}
+	return ""
+}
+
+func isConferenceURL(link string) bool {
+	parsed, err := url.Parse(link)
+	if err != nil {
+		return false
+	}
+
+	host := strings.ToLower(parsed.Hostname())
+	for _, conferenceHost := range conferenceHosts {
+		if host == conferenceHost || strings.HasSuffix(host, "."+conferenceHost) {
+			return true
+		}
+	}
+	return false
+}
+
+// joinURL picks the link to join an event with, preferring structured
+// conference data over links found in the location or description.
+func joinURL(conference *Conference, hangoutLink, location, description string) string {
+	if conference != nil {
+		for _, entryPoint := range conference.EntryPoints {
+			if entryPoint.Type == "video" && entryPoint.URI != "" {
+				return entryPoint.URI
+			}
+		}
+	}
+	if hangoutLink != "" {
+		return hangoutLink
+	}
+	if link := FindConferenceURL(location); link != "" {
+		return link
+	}
+	return FindConferenceURL(description)
+}
+
+// joinMsg reports the outcome of opening a meeting link.
+type joinMsg struct {
+	url    string
+	copied bool
+	err    error
+}
+
+// Status is the line shown to the user after a join attempt.
+func (msg joinMsg) Status() string {
+	switch {
+	case msg.err != nil:
+		return fmt.Sprintf("Failed to open %s: %v", msg.url, msg.err)
+	case msg.copied:
+		return "Copied meeting link to clipboard: " + msg.url
+	default:
+		return "Opened " + msg.url
+	}
+}
+
+// Launcher opens a meeting link. It is a variable so tests can swap it out.
+var Launcher = launchURL
+
+// launchURL opens url in the browser, or copies it to the local clipboard
+// via OSC 52 when running over SSH, where a browser would open remotely.
+func launchURL(url string) tea.Cmd {
+	if os.Getenv("SSH_CONNECTION") != "" || os.Getenv("SSH_TTY") != "" {
+		return tea.Batch(tea.SetClipboard(url), func() tea.Msg {
+			return joinMsg{url: url, copied: true}
+		})
+	}
+
+	return func() tea.Msg {
+		opener := "xdg-open"
+		if runtime.GOOS == "darwin" {
+			opener = "open"
+		}
+		// Run waits for the opener, which hands the link to the browser and
+		// exits, so it is not left behind as a zombie
+		return joinMsg{url: url, err: exec.Command(opener, url).Run()}
+	}
+}
+
+// join returns the command to join e, or a status explaining why it can't.
+func join(e *CalendarEvent) (tea.Cmd, string) {
+	if e == nil {
+		return nil, "No event to join"
+	}
+	if e.JoinURL == "" {
+		return nil, fmt.Sprintf("No meeting link for '%s'", e.Title)
+	}
+	return Launcher(e.JoinURL), ""
+}
@@ -0,0 +1,122 @@
+package calendar
+
+import (
+	"testing"
+	"time"
+
+	tea "charm.land/bubbletea/v2"
+	"github.com/kahnwong/gcal-tui/internal/utils"
+)
+
+func TestFindConferenceURL(t *testing.T) {
+	tests := []struct {
+		name     string
+		text     string
+		expected string
+	}{
+		{"zoom with subdomain", "Join: https://us02web.zoom.us/j/123456789?pwd=abc.", "https://us02web.zoom.us/j/123456789?pwd=abc"},
+		{"teams inside html", `<a href="https://teams.microsoft.com/l/meetup-join/19%3ameeting">Join</a>`, "https://teams.microsoft.com/l/meetup-join/19%3ameeting"},
+		{"webex", "(https://acme.webex.com/meet/alice)", "https://acme.webex.com/meet/alice"},
+		{"skips unrelated links", "Doc: https://docs.google.com/x then https://meet.google.com/abc-defg-hij", "https://meet.google.com/abc-defg-hij"},
+		{"lookalike host is ignored", "https://notzoom.us/j/1", ""},
+		{"no links", "Room 4", ""},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if result := FindConferenceURL(tt.text); result != tt.expected {
+				t.Errorf("FindConferenceURL() = %q, want %q", result, tt.expected)
+			}
+		})
+	}
+}
+
+func TestJoinURL(t *testing.T) {
+	conference := &Conference{EntryPoints: []ConferenceEntryPoint{
+		{Type: "phone", URI: "tel:+1-555-0100"},
+		{Type: "video", URI: "https://meet.google.com/abc-defg-hij"},
+	}}
+
+	tests := []struct {
+		name        string
+		conference  *Conference
+		hangoutLink string
+		location    string
+		description string
+		expected    string
+	}{
+		{"conference data first", conference, "https://meet.google.com/old", "https://zoom.us/j/1", "", "https://meet.google.com/abc-defg-hij"},
+		{"hangout link", nil, "https://meet.google.com/old", "https://zoom.us/j/1", "", "https://meet.google.com/old"},
+		{"location", nil, "", "https://zoom.us/j/1", "https://teams.live.com/meet/2", "https://zoom.us/j/1"},
+		{"description", nil, "", "Room 4", "https://teams.live.com/meet/2", "https://teams.live.com/meet/2"},
+		{"nothing", nil, "", "Room 4", "", ""},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if result := joinURL(tt.conference, tt.hangoutLink, tt.location, tt.description); result != tt.expected {
+				t.Errorf("joinURL() = %q, want %q", result, tt.expected)
+			}
+		})
+	}
+}
+
+// fakeLauncher records opened links instead of starting a browser.
+func fakeLauncher(t *testing.T) *[]string {
+	t.Helper()
+	var opened []string
+	previous := Launcher
+	Launcher = func(url string) tea.Cmd {
+		opened = append(opened, url)
+		return func() tea.Msg { return joinMsg{url: url} }
+	}
+	t.Cleanup(func() { Launcher = previous })
+	return &opened
+}
+
+func TestModelJoinKey(t *testing.T) {
+	opened := fakeLauncher(t)
+
+	now := utils.Now()
+	m := Model{
+		StartDate:   utils.StartOfDay(now),
+		ColumnCount: 2, // so the events stay visible when run shortly before midnight
+		Events: []CalendarEvent{
+			{Title: "Later", StartTime: now.Add(2 * time.Hour), EndTime: now.Add(3 * time.Hour), JoinURL: "https://zoom.us/j/later"},
+			{Title: "Soon", StartTime: now.Add(time.Hour), EndTime: now.Add(2 * time.Hour), JoinURL: "https://meet.google.com/soon"},
+			{Title: "Over", StartTime: now.Add(-2 * time.Hour), EndTime: now.Add(-time.Hour), JoinURL: "https://meet.google.com/over"},
+		},
+	}
+
+	updated, cmd := m.Update(tea.KeyPressMsg{Code: 'j', Text: "j"})
+	if cmd == nil {
+		t.Fatal("Expected a command to open the link")
+	}
+	if len(*opened) != 1 || (*opened)[0] != "https://meet.google.com/soon" {
+		t.Errorf("Expected the next meeting to be joined, got %q", *opened)
+	}
+
+	updated, _ = updated.Update(cmd())
+	if status := updated.(Model).Status; status != "Opened https://meet.google.com/soon" {
+		t.Errorf("Unexpected status %q", status)
+	}
+}
+
+func TestNextMeetingModelJoinKey(t *testing.T) {
+	opened := fakeLauncher(t)
+
+	m := NextMeetingModel{nextEvent: &CalendarEvent{Title: "Standup"}}
+	updated, cmd := m.Update(tea.KeyPressMsg{Code: 'j', Text: "j"})
+	if cmd != nil || len(*opened) != 0 {
+		t.Error("Expected nothing to be opened for an event without a link")
+	}
+	if status := updated.(NextMeetingModel).status; status != "No meeting link for 'Standup'" {
+		t.Errorf("Unexpected status %q", status)
+	}
+
+	m.nextEvent.JoinURL = "https://meet.google.com/abc-defg-hij"
+	if _, cmd = m.Update(tea.KeyPressMsg{Code: 'j', Text: "j"}); cmd == nil {
+		t.Fatal("Expected a command to open the link")
+	}
+	if len(*opened) != 1 || (*opened)[0] != "https://meet.google.com/abc-defg-hij" {
+		t.Errorf("Expected the next meeting to be joined, got %q", *opened)
+	}
+}
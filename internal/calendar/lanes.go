@@ -0,0 +1,240 @@
+package calendar
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/kahnwong/gcal-tui/internal/utils"
+)
+
+// minLaneWidth is the narrowest lane drawn for overlapping events. When the
+// lanes of a column would be narrower, the last one shows "+N" instead.
+const minLaneWidth = 3
+
+// laneEvent is the part of an event drawn in one day column, and the lane it
+// takes among the events it overlaps.
+type laneEvent struct {
+	event      CalendarEvent
+	start, end time.Time
+	lane       int // index from the left
+	lanes      int // lanes in its overlap group
+}
+
+// dayLanes lays out the timed events of the day starting at dayStart in
+// lanes, so that overlapping events are drawn side by side. Events that
+// overlap, directly or through others, form a group sharing the same number
+// of lanes.
+func (m Model) dayLanes(dayStart time.Time) []laneEvent {
+	var segments []laneEvent
+	for _, e := range m.Events {
+		if inBanner(e) || e.Hidden() || isBackground(e) {
+			continue
+		}
+		if start, end, ok := clipToDay(e, dayStart); ok {
+			segments = append(segments, laneEvent{event: e, start: start, end: end})
+		}
+	}
+	sort.SliceStable(segments, func(i, j int) bool {
+		return segments[i].start.Before(segments[j].start)
+	})
+
+	groupStart := 0
+	var lastInLane []laneEvent
+	var latest laneEvent // the event of the group that ends last
+	closeGroup := func(end int) {
+		for i := groupStart; i < end; i++ {
+			segments[i].lanes = len(lastInLane)
+		}
+	}
+	for i, s := range segments {
+		if i > 0 && m.fitsAfter(latest, s) {
+			closeGroup(i)
+			groupStart, lastInLane = i, nil
+		}
+
+		lane := len(lastInLane)
+		for l, last := range lastInLane {
+			if m.fitsAfter(last, s) {
+				lane = l
+				break
+			}
+		}
+		if lane == len(lastInLane) {
+			lastInLane = append(lastInLane, s)
+		} else {
+			lastInLane[lane] = s
+		}
+		segments[i].lane = lane
+
+		if groupStart == i || s.end.After(latest.end) {
+			latest = s
+		}
+	}
+	closeGroup(len(segments))
+	return segments
+}
+
+// fitsAfter reports whether next can be drawn below prev in the same lane:
+// it starts in a later cell, or prev only takes the upper half of the cell
+// next starts in and next the lower half.
+func (m Model) fitsAfter(prev, next laneEvent) bool {
+	prevLast := m.cellOf(prev.end.Add(-time.Nanosecond))
+	nextFirst := m.cellOf(next.start)
+	if prevLast != nextFirst {
+		return prevLast < nextFirst
+	}
+	cell := m.cellStart(next.start)
+	return m.coverage(prev.start, prev.end, cell) == coverTop && m.coverage(next.start, next.end, cell) == coverBottom
+}
+
+// cellOf returns the cell holding t, counted from midnight on its day.
+func (m Model) cellOf(t time.Time) int {
+	t = t.In(m.location())
+	return (t.Hour()*60 + t.Minute()) / int(m.slot()/time.Minute)
+}
+
+// cellStart returns the start of the cell holding t.
+func (m Model) cellStart(t time.Time) time.Time {
+	return utils.AtTimeIn(t, 0, m.cellOf(t)*int(m.slot()/time.Minute), m.location())
+}
+
+// gridCell holds what is needed to draw the cell of one day at one row.
+type gridCell struct {
+	dayStart    time.Time
+	start       time.Time // start of the cell
+	viewportTop time.Time // start of the first visible row on the day
+	now         time.Time
+	nowLine     bool // the now-line runs through this cell
+}
+
+// renderCell draws the cell of a day column, split into lanes when events
+// overlap it.
+func (m Model) renderCell(lanes []laneEvent, c gridCell) string {
+	end := c.start.Add(m.slot())
+	var touching []laneEvent
+	count := 0
+	for _, l := range lanes {
+		if l.start.Before(end) && l.end.After(c.start) {
+			touching = append(touching, l)
+			count = max(count, l.lanes)
+		}
+	}
+	if count == 0 {
+		return m.backgroundCell(c, m.ColWidth)
+	}
+
+	shown := min(count, (m.ColWidth+1)/(minLaneWidth+1))
+	shown = max(shown, 1)
+	var parts []string
+	for lane := range shown {
+		// lanes share the column, the leftmost ones taking the remainder
+		width := (m.ColWidth - (shown - 1)) / shown
+		if lane < (m.ColWidth-(shown-1))%shown {
+			width++
+		}
+		if lane > 0 {
+			parts = append(parts, m.backgroundCell(c, 1))
+		}
+
+		if lane == shown-1 && count > shown {
+			overflow := 0
+			style := OverflowStyle
+			for _, l := range touching {
+				if l.lane >= lane {
+					overflow++
+					if eventKey(l.event) == m.Cursor {
+						// the cursor is on one of the folded events
+						style = style.Reverse(true)
+					}
+				}
+			}
+			if overflow > 0 {
+				parts = append(parts, style.Width(width).Render(truncate(fmt.Sprintf("+%d", overflow), width)))
+				continue
+			}
+		}
+
+		var inLane []laneEvent
+		for _, l := range touching {
+			if l.lane == lane {
+				inLane = append(inLane, l)
+			}
+		}
+		if cell, ok := m.laneCell(inLane, c, width); ok {
+			parts = append(parts, cell)
+		} else {
+			parts = append(parts, m.backgroundCell(c, width))
+		}
+	}
+	return strings.Join(parts, "")
+}
+
+// laneCell draws the events of one lane in a cell. Lanes never hold
+// overlapping events, so this is a single event, or one ending in the upper
+// half of the cell and another starting in the lower half.
+func (m Model) laneCell(events []laneEvent, c gridCell, width int) (string, bool) {
+	var endsAbove, startsBelow *CalendarEvent
+	for _, l := range events {
+		switch m.coverage(l.start, l.end, c.start) {
+		case coverTop:
+			endsAbove = &l.event
+			continue
+		case coverBottom:
+			startsBelow = &l.event
+			continue
+		}
+
+		// the title starts in the first fully drawn row, or at the top of
+		// the viewport for events scrolled into
+		shown := m.cellStart(l.start)
+		if m.coverage(l.start, l.end, shown) == coverBottom {
+			shown = shown.Add(m.slot())
+		}
+		if c.viewportTop.After(shown) {
+			shown = c.viewportTop
+		}
+		chunkIndex := int(c.start.Sub(shown) / m.slot())
+		chunks := chunkTitle(segmentTitle(l.event, l.start), max(width-2, 1))
+
+		text := ""
+		if chunkIndex >= 0 && chunkIndex < len(chunks) {
+			text = chunks[chunkIndex]
+		}
+		e := l.event
+		style := eventStyle(e)
+		if c.now.After(e.StartTime) && c.now.Before(e.EndTime) && !e.Dimmed() {
+			style = EventStyleActive.Background(GetColorValue(e.Color))
+		}
+		if eventKey(e) == m.Cursor {
+			style = style.Reverse(true)
+		}
+		return style.Width(width).Render(text), true
+	}
+
+	if endsAbove == nil && startsBelow == nil {
+		return "", false
+	}
+	return partialCell(endsAbove, startsBelow, width), true
+}
+
+// backgroundCell draws an empty cell or lane: the now-line, out of office
+// shading, or nothing.
+func (m Model) backgroundCell(c gridCell, width int) string {
+	if c.nowLine {
+		return NowLineStyle.Width(width).Render(strings.Repeat("─", width))
+	}
+	if e, segStart, ok := m.outOfOfficeAt(c.start, c.dayStart); ok {
+		label := ""
+		if c.start.Equal(segStart) || c.start.Equal(c.viewportTop) {
+			label = truncate(segmentTitle(e, segStart), width)
+		}
+		style := OutOfOfficeStyle
+		if eventKey(e) == m.Cursor {
+			style = style.Reverse(true)
+		}
+		return style.Width(width).Render(hatch(label, width))
+	}
+	return emptyStyle(c.dayStart).Width(width).Render("")
+}
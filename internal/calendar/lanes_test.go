@@ -0,0 +1,135 @@
+package calendar
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/kahnwong/gcal-tui/internal/utils"
+)
+
+func TestDayLanes(t *testing.T) {
+	original := utils.Location
+	utils.Location = time.UTC
+	defer func() { utils.Location = original }()
+
+	day := time.Date(2026, 2, 2, 0, 0, 0, 0, time.UTC)
+	at := func(hour, min int) time.Time {
+		return day.Add(time.Duration(hour)*time.Hour + time.Duration(min)*time.Minute)
+	}
+	m := Model{
+		StartDate: day,
+		Events: []CalendarEvent{
+			{Title: "Standup", StartTime: at(9, 0), EndTime: at(9, 30)},
+			{Title: "Interview", StartTime: at(9, 0), EndTime: at(10, 0)},
+			{Title: "Sync", StartTime: at(9, 30), EndTime: at(10, 30)},
+			{Title: "Lunch", StartTime: at(12, 0), EndTime: at(13, 0)},
+			{Title: "Quick", StartTime: at(14, 0), EndTime: at(14, 10)},
+			{Title: "Call", StartTime: at(14, 10), EndTime: at(14, 30)},
+			{Title: "Handover", StartTime: at(15, 0), EndTime: at(15, 45)},
+			{Title: "Retro", StartTime: at(15, 45), EndTime: at(16, 30)},
+		},
+	}
+
+	want := map[string][2]int{ // lane, lanes
+		"Standup":   {0, 2},
+		"Interview": {1, 2},
+		"Sync":      {0, 2},
+		"Lunch":     {0, 1},
+		"Quick":     {0, 2}, // both would take the whole 14:00 row
+		"Call":      {1, 2},
+		"Handover":  {0, 1}, // shares the 15:30 row in halves
+		"Retro":     {0, 1},
+	}
+	lanes := m.dayLanes(day)
+	if len(lanes) != len(want) {
+		t.Fatalf("Expected %d events, got %d", len(want), len(lanes))
+	}
+	for _, l := range lanes {
+		if got := [2]int{l.lane, l.lanes}; got != want[l.event.Title] {
+			t.Errorf("%s: (lane, lanes) = %v, want %v", l.event.Title, got, want[l.event.Title])
+		}
+	}
+}
+
+func TestRenderCellLanes(t *testing.T) {
+	original := utils.Location
+	utils.Location = time.UTC
+	defer func() { utils.Location = original }()
+
+	day := time.Date(2026, 2, 2, 0, 0, 0, 0, time.UTC)
+	at := func(hour int) time.Time { return day.Add(time.Duration(hour) * time.Hour) }
+	events := []CalendarEvent{
+		{Title: "Alpha", StartTime: at(9), EndTime: at(10)},
+		{Title: "Bravo", StartTime: at(9), EndTime: at(10)},
+		{Title: "Charlie", StartTime: at(9), EndTime: at(10)},
+	}
+	cell := gridCell{dayStart: day, start: at(9), viewportTop: at(9), now: day.AddDate(0, 0, 1)}
+
+	tests := []struct {
+		name     string
+		colWidth int
+		want     []string
+		notWant  []string
+	}{
+		{"side by side", 30, []string{"Al", "Br", "Ch"}, []string{"+"}},
+		{"too narrow", 8, []string{"Al", "+2"}, []string{"Br", "Ch"}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			m := Model{StartDate: day, ColumnCount: 1, ColWidth: tt.colWidth, Events: events}
+			result := m.renderCell(m.dayLanes(day), cell)
+			for _, want := range tt.want {
+				if !strings.Contains(result, want) {
+					t.Errorf("Expected %q in %q", want, result)
+				}
+			}
+			for _, notWant := range tt.notWant {
+				if strings.Contains(result, notWant) {
+					t.Errorf("Expected no %q in %q", notWant, result)
+				}
+			}
+		})
+	}
+}
+
+func TestRenderCellCursor(t *testing.T) {
+	original := utils.Location
+	utils.Location = time.UTC
+	defer func() { utils.Location = original }()
+
+	day := time.Date(2026, 2, 2, 0, 0, 0, 0, time.UTC)
+	at := func(hour int) time.Time { return day.Add(time.Duration(hour) * time.Hour) }
+	ooo := CalendarEvent{Title: "Out of office", EventType: EventTypeOutOfOffice, StartTime: at(13), EndTime: at(17)}
+	folded := CalendarEvent{Title: "Charlie", StartTime: at(9), EndTime: at(10)}
+	events := []CalendarEvent{
+		{Title: "Alpha", StartTime: at(9), EndTime: at(10)},
+		{Title: "Bravo", StartTime: at(9), EndTime: at(10)},
+		folded,
+		ooo,
+	}
+
+	tests := []struct {
+		name   string
+		cursor CalendarEvent
+		hour   int
+	}{
+		{"folded into +N", folded, 9},
+		{"out of office", ooo, 13},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			m := Model{StartDate: day, ColumnCount: 1, ColWidth: 8, Events: events}
+			cell := gridCell{dayStart: day, start: at(tt.hour), viewportTop: at(tt.hour), now: day.AddDate(0, 0, 1)}
+			plain := m.renderCell(m.dayLanes(day), cell)
+
+			m.Cursor = eventKey(tt.cursor)
+			if m.cursorEvent() == nil {
+				t.Fatal("Expected the event to be selectable")
+			}
+			if m.renderCell(m.dayLanes(day), cell) == plain {
+				t.Errorf("Expected the cursor to be drawn, got %q", plain)
+			}
+		})
+	}
+}
@@ -0,0 +1,200 @@
+package calendar
+
+import (
+	"log/slog"
+	"time"
+
+	tea "charm.land/bubbletea/v2"
+	"github.com/kahnwong/gcal-tui/configs"
+	"github.com/kahnwong/gcal-tui/internal/utils"
+)
+
+// Fetcher loads the events overlapping [start, end). It is a
+// variable so tests can run the models without the Google Calendar API.
+var Fetcher = FetchAllEvents
+
+// RefreshInterval is how often the interactive views fetch the visible range
+// again. Zero turns auto-refresh off.
+var RefreshInterval = configs.DefaultRefreshInterval
+
+// refreshMsg asks the views to fetch the visible range again.
+type refreshMsg struct{}
+
+// tickRefresh returns a command that sends the next refreshMsg, or nil when
+// auto-refresh is off.
+func tickRefresh() tea.Cmd {
+	if RefreshInterval <= 0 {
+		return nil
+	}
+	return tea.Tick(RefreshInterval, func(time.Time) tea.Msg {
+		return refreshMsg{}
+	})
+}
+
+// eventsMsg carries the result of a fetch started by loader.fetch or
+// loader.prefetch. Request identifies the fetch so responses for a range the
+// user has already navigated away from are only cached, not shown; prefetches
+// use request 0.
+type eventsMsg struct {
+	request int
+	start   time.Time
+	days    int
+	events  []CalendarEvent
+	err     error
+	at      time.Time // when the fetch finished
+}
+
+// fetchCmd returns a command that fetches the given number of days from start
+// in the background and reports back with an eventsMsg.
+func fetchCmd(start time.Time, days, request int) tea.Cmd {
+	return func() tea.Msg {
+		events, err := Fetcher(start, start.AddDate(0, 0, days))
+		return eventsMsg{request: request, start: start, days: days, events: events, err: err, at: utils.Now()}
+	}
+}
+
+// spinnerFrames are cycled through while a fetch is in flight.
+var spinnerFrames = []string{"⠋", "⠙", "⠹", "⠸", "⠼", "⠴", "⠦", "⠧", "⠇", "⠏"}
+
+// spinnerMsg advances the loading spinner by one frame.
+type spinnerMsg struct{}
+
+// tickSpinner returns a command that sends the next spinnerMsg.
+func tickSpinner() tea.Cmd {
+	return tea.Tick(100*time.Millisecond, func(time.Time) tea.Msg {
+		return spinnerMsg{}
+	})
+}
+
+// spinnerFrame returns the frame to draw for the n-th spinner tick.
+func spinnerFrame(n int) string {
+	return spinnerFrames[n%len(spinnerFrames)]
+}
+
+// loader tracks the background fetches of a view: the latest request, the
+// spinner drawn while it is in flight and the ranges fetched so far. The views
+// embed it, so its fields are theirs.
+type loader struct {
+	Warning string    // Calendars that failed to load, see FetchWarning
+	Loading bool      // A fetch is in flight, the previous events are shown meanwhile
+	Request int       // Number of the latest fetch, older responses are dropped
+	Spinner int       // Frame of the loading spinner
+	Updated time.Time // When the shown events were fetched
+
+	cache windowCache // recently visited and prefetched ranges
+}
+
+// newLoader returns the loader of a view whose first fetch, request 1, is
+// started by its Init along with the spinner.
+func newLoader() loader {
+	return loader{Loading: true, Request: 1}
+}
+
+// begin makes a new fetch the latest request and returns its number. The
+// first spinner tick is returned as well unless spinning reports that a chain
+// of ticks is already running, as it is while the view is loading.
+func (l *loader) begin(spinning bool) (int, tea.Cmd) {
+	l.Request++
+	l.Loading = true
+	if spinning {
+		return l.Request, nil
+	}
+	return l.Request, tickSpinner()
+}
+
+// fetch starts fetching the given number of days from start in the
+// background, keeping the shown events until the response arrives.
+func (l *loader) fetch(start time.Time, days int) tea.Cmd {
+	request, spin := l.begin(l.Loading)
+	return tea.Batch(fetchCmd(start, days, request), spin)
+}
+
+// cached returns the cached events of the days from start, taking their
+// warning and update time, so they can be shown while they are fetched again.
+func (l *loader) cached(start time.Time, days int) ([]CalendarEvent, bool) {
+	w, ok := l.cache.get(start, days)
+	if !ok {
+		return nil, false
+	}
+	l.Warning = w.warning
+	l.Updated = w.updated
+	return w.events, true
+}
+
+// receive caches a fetch result and reports whether it answers the latest
+// request, in which case the view shows its events. Calendars that fail to
+// load are named in Warning while the rest are still shown.
+func (l *loader) receive(msg eventsMsg) bool {
+	current := msg.request == l.Request
+	if _, cached := l.cache.get(msg.start, msg.days); current || (msg.err == nil && !cached) {
+		// failed or late prefetches don't replace what a real load returned
+		l.cache = l.cache.put(msg.start, msg.days, msg.events, FetchWarning(msg.err), msg.at)
+	}
+	if !current {
+		return false
+	}
+
+	if msg.err != nil {
+		slog.Warn("Error fetching events", "error", msg.err)
+	}
+	l.Warning = FetchWarning(msg.err)
+	l.Updated = msg.at
+	l.Loading = false
+	return true
+}
+
+// prefetch drops cached ranges far from the days shown from start, then
+// fetches the previous and next range, period days away, in the background
+// unless they are already cached.
+func (l loader) prefetch(start time.Time, days, period int) tea.Cmd {
+	l.cache.prune(start, period)
+	var cmds []tea.Cmd
+	for _, offset := range []int{-period, period} {
+		from := start.AddDate(0, 0, offset)
+		if _, ok := l.cache.get(from, days); !ok {
+			cmds = append(cmds, fetchCmd(from, days, 0))
+		}
+	}
+	return tea.Batch(cmds...)
+}
+
+// spin advances the spinner while a fetch is in flight.
+func (l *loader) spin() {
+	if l.Loading {
+		l.Spinner++
+	}
+}
+
+// tick handles a spinnerMsg for a view with its own chain of ticks, which
+// stops once nothing is loading.
+func (l *loader) tick() tea.Cmd {
+	if !l.Loading {
+		return nil
+	}
+	l.spin()
+	return tickSpinner()
+}
+
+// statusLines returns the lines shown above the key help: the spinner, or when
+// the events were last updated in loc, followed by the warning if any.
+func (l loader) statusLines(loc *time.Location) []string {
+	var lines []string
+	if l.Loading {
+		lines = append(lines, LoadingStyle.Render(spinnerFrame(l.Spinner)+" Loading events..."))
+	} else if !l.Updated.IsZero() {
+		lines = append(lines, SecondaryTimeLabelStyle.Render("Last updated: "+l.Updated.In(loc).Format("15:04:05")))
+	}
+	if l.Warning != "" {
+		lines = append(lines, WarningStyle.Render(l.Warning))
+	}
+	return lines
+}
+
+// viewTime returns now, the time a view is drawn for, in loc. It is the
+// current time until the view's first nowMsg arrives.
+func viewTime(now time.Time, loc *time.Location) time.Time {
+	if now.IsZero() {
+		now = utils.Now()
+	}
+	return now.In(loc)
+}
@@ -0,0 +1,324 @@
+package calendar
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	tea "charm.land/bubbletea/v2"
+	"charm.land/lipgloss/v2"
+	"github.com/kahnwong/gcal-tui/internal/utils"
+)
+
+// Styles for the month view
+var (
+	MonthTitleStyle     = lipgloss.NewStyle().Bold(true).Foreground(lipgloss.Color("#FFF")).Align(lipgloss.Center)
+	DayNumberStyle      = lipgloss.NewStyle().Background(lipgloss.Color("#333")).Foreground(lipgloss.Color("#FFF")).Bold(true)
+	DayNumberOffStyle   = lipgloss.NewStyle().Background(lipgloss.Color("#333")).Foreground(lipgloss.Color("#777"))
+	DayNumberTodayStyle = lipgloss.NewStyle().Background(lipgloss.Color("#333")).Foreground(lipgloss.Color("#FF0000")).Bold(true)
+)
+
+// Month view sizes, used until the terminal size is known
+const (
+	defaultMonthColWidth  = 14
+	defaultMonthRowHeight = 5 // day number plus four events
+	minMonthRowHeight     = 2
+)
+
+// MonthModel shows a month as a grid of weeks, each day listing its events.
+// A day can be opened in the day or week view, see Grid.
+type MonthModel struct {
+	Events   []CalendarEvent
+	Month    time.Time // First day of the shown month
+	Selected time.Time // Start of the day under the cursor
+	Width    int       // Terminal width, from tea.WindowSizeMsg
+	Height   int       // Terminal height, from tea.WindowSizeMsg
+	Now      time.Time // Current time, for marking today
+	Grid     *Model    // Day or week view opened from the month, "m" comes back
+
+	loader
+}
+
+// monthEventsMsg carries the result of a fetch started by MonthModel.load. It
+// is separate from eventsMsg so a month fetch landing while a day or week is
+// open is not taken for that view's.
+type monthEventsMsg struct {
+	eventsMsg
+}
+
+// NewMonthModel creates a month view of the current month with today
+// selected. The events are fetched once the program starts, see Init.
+func NewMonthModel() MonthModel {
+	now := utils.Now()
+	today := utils.StartOfDay(now)
+	return MonthModel{
+		Month:    today.AddDate(0, 0, 1-today.Day()),
+		Selected: today,
+		Now:      now,
+		loader:   newLoader(),
+	}
+}
+
+// gridStart returns the day the month grid starts on, the first day of the
+// week holding the 1st.
+func (m MonthModel) gridStart() time.Time {
+	return startOfWeek(m.Month)
+}
+
+// weeks returns the number of week rows needed to show the whole month.
+func (m MonthModel) weeks() int {
+	days := dayIndex(m.gridStart(), m.Month.AddDate(0, 1, 0))
+	return (days + 6) / 7
+}
+
+// fetchCmd returns a command that fetches the days shown in the grid in the
+// background as the given request and reports back with a monthEventsMsg.
+func (m MonthModel) fetchCmd(request int) tea.Cmd {
+	fetch := fetchCmd(m.gridStart(), 7*m.weeks(), request)
+	return func() tea.Msg {
+		return monthEventsMsg{fetch().(eventsMsg)}
+	}
+}
+
+// load starts fetching the shown month in the background. A month visited
+// before is shown right away while it is fetched again, otherwise the current
+// events stay on screen until the response arrives.
+func (m MonthModel) load() (MonthModel, tea.Cmd) {
+	if events, ok := m.cached(m.gridStart(), 7*m.weeks()); ok {
+		m.Events = events
+	}
+	request, spin := m.begin(m.busy())
+	return m, tea.Batch(m.fetchCmd(request), spin)
+}
+
+// busy reports whether the month or the grid opened from it is loading. They
+// share one chain of spinner ticks, which runs for as long as this holds.
+func (m MonthModel) busy() bool {
+	return m.Loading || (m.Grid != nil && m.Grid.Loading)
+}
+
+// selectDay moves the cursor to day, loading its month when it lies outside
+// the shown one.
+func (m MonthModel) selectDay(day time.Time) (MonthModel, tea.Cmd) {
+	m.Selected = day
+	first := day.AddDate(0, 0, 1-day.Day())
+	if first.Equal(m.Month) {
+		return m, nil
+	}
+	m.Month = first
+	return m.load()
+}
+
+// selectMonth moves to the month offset months away, keeping the day of the
+// month where possible.
+func (m MonthModel) selectMonth(offset int) (MonthModel, tea.Cmd) {
+	first := m.Month.AddDate(0, offset, 0)
+	last := first.AddDate(0, 1, -1)
+	return m.selectDay(first.AddDate(0, 0, min(m.Selected.Day(), last.Day())-1))
+}
+
+// openGrid opens the selected day, or its week, in the grid view. The month's
+// events are shown while the grid fetches its own.
+func (m MonthModel) openGrid(week bool) (MonthModel, tea.Cmd) {
+	grid := NewModelAt(m.Selected, 1, 20)
+	if week {
+		grid = NewModelAt(startOfWeek(m.Selected), 7, 20)
+		if HideWeekends {
+			grid = grid.withWeekends(false)
+		}
+	}
+	grid.Events = m.Events
+	grid.Updated = m.Updated
+	grid.InMonth = true
+	if m.Width > 0 {
+		grid = grid.layout(m.Width, m.Height)
+	} else {
+		grid = grid.autoScroll()
+	}
+	request, spin := grid.begin(m.busy())
+	m.Grid = &grid
+	return m, tea.Batch(fetchCmd(grid.StartDate, grid.ColumnCount, request), spin)
+}
+
+func (m MonthModel) Init() tea.Cmd {
+	return tea.Batch(tickNow(), tickRefresh(), m.fetchCmd(m.Request), tickSpinner())
+}
+
+func (m MonthModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
+	switch msg := msg.(type) {
+	case tea.WindowSizeMsg:
+		m.Width, m.Height = msg.Width, msg.Height
+	case monthEventsMsg:
+		// handled here even while a grid is open, so the month stays current
+		if m.receive(msg.eventsMsg) {
+			m.Events = msg.events
+			m.cache.prune(m.gridStart(), 7*m.weeks())
+		}
+		return m, nil
+	case spinnerMsg:
+		// handled here so the month and the grid share one chain of ticks
+		if !m.busy() {
+			return m, nil
+		}
+		m.spin()
+		if m.Grid != nil {
+			grid := *m.Grid
+			grid.spin()
+			m.Grid = &grid
+		}
+		return m, tickSpinner()
+	}
+
+	if m.Grid != nil {
+		if key, ok := msg.(tea.KeyPressMsg); ok && key.String() == "m" {
+			// the spinner keeps ticking if the month is still loading
+			m.Grid = nil
+			return m, nil
+		}
+		updated, cmd := m.Grid.Update(msg)
+		grid := updated.(Model)
+		m.Grid = &grid
+		return m, cmd
+	}
+
+	switch msg := msg.(type) {
+	case nowMsg:
+		m.Now = time.Time(msg).In(utils.Location)
+		return m, tickNow()
+	case refreshMsg:
+		var cmd tea.Cmd
+		m, cmd = m.load()
+		return m, tea.Batch(cmd, tickRefresh())
+	case tea.KeyPressMsg:
+		switch msg.String() {
+		case "ctrl+c", "q":
+			return m, tea.Quit
+		case "left":
+			return m.selectDay(m.Selected.AddDate(0, 0, -1))
+		case "right":
+			return m.selectDay(m.Selected.AddDate(0, 0, 1))
+		case "up":
+			return m.selectDay(m.Selected.AddDate(0, 0, -7))
+		case "down":
+			return m.selectDay(m.Selected.AddDate(0, 0, 7))
+		case "[", "pgup":
+			return m.selectMonth(-1)
+		case "]", "pgdown":
+			return m.selectMonth(1)
+		case "t":
+			return m.selectDay(utils.StartOfDay(viewTime(m.Now, utils.Location)))
+		case "enter":
+			return m.openGrid(false)
+		case "w":
+			return m.openGrid(true)
+		case "r":
+			return m.load()
+		}
+	}
+	return m, nil
+}
+
+// cellSize returns the width of a day column and the height of a week row,
+// fitted to the terminal once its size is known.
+func (m MonthModel) cellSize() (width, height int) {
+	if m.Width <= 0 || m.Height <= 0 {
+		return defaultMonthColWidth, defaultMonthRowHeight
+	}
+	width = max((m.Width-BorderStyle.GetHorizontalFrameSize()-6)/7, MinColWidth)
+	header := 2 // month title and weekday names
+	available := m.Height - BorderStyle.GetVerticalFrameSize() - header - lipgloss.Height(m.footer(7*width+6))
+	return width, max(available/m.weeks(), minMonthRowHeight)
+}
+
+// eventLine renders one event in a day cell, prefixed with its start time
+// unless it lasts all day.
+func eventLine(e CalendarEvent, day time.Time, width int) string {
+	start, _, _ := clipToDay(e, day)
+	title := segmentTitle(e, start)
+	if !inBanner(e) && start.Equal(e.StartTime) {
+		title = e.StartTime.In(utils.Location).Format("15:04") + " " + title
+	}
+	style := eventStyle(e)
+	if e.EventType == EventTypeOutOfOffice {
+		style = OutOfOfficeStyle
+	}
+	return style.Width(width).Render(truncate(title, width))
+}
+
+// renderDay draws the cell of one day: its number, then as many events as
+// fit, the last line counting the rest.
+func (m MonthModel) renderDay(day time.Time, width, height int) []string {
+	style := DayNumberStyle
+	switch {
+	case dateDiff(day, viewTime(m.Now, utils.Location)) == 0:
+		style = DayNumberTodayStyle
+	case day.Month() != m.Month.Month():
+		style = DayNumberOffStyle
+	}
+	if day.Equal(m.Selected) {
+		style = style.Reverse(true)
+	}
+	lines := []string{style.Width(width).Render(fmt.Sprintf("%2d", day.Day()))}
+
+	events := eventsOn(m.Events, day)
+	room := height - 1
+	shown := events
+	if len(events) > room {
+		shown = events[:max(room-1, 0)]
+	}
+	for _, e := range shown {
+		lines = append(lines, eventLine(e, day, width))
+	}
+	if hidden := len(events) - len(shown); hidden > 0 && room > 0 {
+		lines = append(lines, OverflowStyle.Width(width).Render(truncate(fmt.Sprintf("+%d more", hidden), width)))
+	}
+	for len(lines) < height {
+		lines = append(lines, emptyStyle(day).Width(width).Render(""))
+	}
+	return lines
+}
+
+func (m MonthModel) View() tea.View {
+	if m.Grid != nil {
+		return m.Grid.View()
+	}
+
+	v := tea.NewView("")
+	v.AltScreen = true
+
+	width, height := m.cellSize()
+	gridWidth := 7*width + 6
+	separator := SeparatorStyle.Width(1).Render("|")
+
+	rows := []string{MonthTitleStyle.Width(gridWidth).Render(m.Month.Format("January 2006"))}
+	var names []string
+	for d := range 7 {
+		day := m.gridStart().AddDate(0, 0, d)
+		names = append(names, headerStyle(day).Width(width).Render(truncate(day.Format("Monday"), width)))
+	}
+	rows = append(rows, strings.Join(names, separator))
+
+	for w := range m.weeks() {
+		cells := make([][]string, 7)
+		for d := range 7 {
+			cells[d] = m.renderDay(m.gridStart().AddDate(0, 0, 7*w+d), width, height)
+		}
+		for line := range height {
+			var parts []string
+			for d := range 7 {
+				parts = append(parts, cells[d][line])
+			}
+			rows = append(rows, strings.Join(parts, separator))
+		}
+	}
+
+	v.SetContent(BorderStyle.Render(strings.Join(rows, "\n") + "\n" + m.footer(gridWidth)))
+	return v
+}
+
+// footer renders the key help below the month, preceded by status lines and
+// wrapped to width.
+func (m MonthModel) footer(width int) string {
+	lines := append(m.statusLines(utils.Location), "←/→/↑/↓: Move   [/]: Prev/Next month   t: Today   enter: Day   w: Week   r: Refresh   q: Quit")
+	return lipgloss.NewStyle().Width(width).Render(strings.Join(lines, "\n"))
+}
@@ -0,0 +1,206 @@
+package calendar
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	tea "charm.land/bubbletea/v2"
+	"github.com/kahnwong/gcal-tui/internal/utils"
+)
+
+func TestMonthGrid(t *testing.T) {
+	tests := []struct {
+		name      string
+		month     time.Time
+		wantStart time.Time
+		wantWeeks int
+	}{
+		{"starts on a Sunday", time.Date(2026, 2, 1, 0, 0, 0, 0, time.UTC), time.Date(2026, 1, 26, 0, 0, 0, 0, time.UTC), 5},
+		{"starts on a Monday", time.Date(2026, 6, 1, 0, 0, 0, 0, time.UTC), time.Date(2026, 6, 1, 0, 0, 0, 0, time.UTC), 5},
+		{"four weeks", time.Date(2027, 2, 1, 0, 0, 0, 0, time.UTC), time.Date(2027, 2, 1, 0, 0, 0, 0, time.UTC), 4},
+		{"six weeks", time.Date(2026, 8, 1, 0, 0, 0, 0, time.UTC), time.Date(2026, 7, 27, 0, 0, 0, 0, time.UTC), 6},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			m := MonthModel{Month: tt.month}
+			if got := m.gridStart(); !got.Equal(tt.wantStart) {
+				t.Errorf("gridStart() = %v, want %v", got, tt.wantStart)
+			}
+			if got := m.weeks(); got != tt.wantWeeks {
+				t.Errorf("weeks() = %d, want %d", got, tt.wantWeeks)
+			}
+		})
+	}
+}
+
+func TestMonthDay(t *testing.T) {
+	original := utils.Location
+	utils.Location = time.UTC
+	defer func() { utils.Location = original }()
+
+	day := time.Date(2026, 2, 4, 0, 0, 0, 0, time.UTC)
+	at := func(hour int) time.Time { return day.Add(time.Duration(hour) * time.Hour) }
+	m := MonthModel{
+		Month:    time.Date(2026, 2, 1, 0, 0, 0, 0, time.UTC),
+		Selected: day,
+		Now:      day,
+		Events: []CalendarEvent{
+			{Title: "Review", StartTime: at(14), EndTime: at(15), Color: "#00FF00"},
+			{Title: "Standup", StartTime: at(9), EndTime: at(10), Color: "#00FF00"},
+			{Title: "Holiday", StartTime: day, EndTime: day.AddDate(0, 0, 1), AllDay: true},
+			{Title: "Trip", StartTime: day.AddDate(0, 0, -1), EndTime: day.AddDate(0, 0, 2), AllDay: true},
+			{Title: "Office", StartTime: day, EndTime: day.AddDate(0, 0, 1), AllDay: true, EventType: EventTypeWorkingLocation},
+		},
+	}
+
+	var titles []string
+	for _, e := range eventsOn(m.Events, day) {
+		titles = append(titles, e.Title)
+	}
+	if got, want := strings.Join(titles, ", "), "Trip, Holiday, Standup, Review"; got != want {
+		t.Errorf("eventsOn() = %s, want %s", got, want)
+	}
+
+	lines := m.renderDay(day, 14, 4)
+	if len(lines) != 4 {
+		t.Fatalf("Expected 4 lines, got %d", len(lines))
+	}
+	for i, want := range []string{" 4", "cont. Trip", "Holiday", "+2 more"} {
+		if !strings.Contains(lines[i], want) {
+			t.Errorf("Line %d = %q, want it to contain %q", i, lines[i], want)
+		}
+	}
+
+	lines = m.renderDay(day, 14, 6)
+	if !strings.Contains(lines[3], "09:00 Standup") {
+		t.Errorf("Expected timed events to show their start time, got %q", lines[3])
+	}
+}
+
+func TestMonthNavigation(t *testing.T) {
+	original := Fetcher
+	defer func() { Fetcher = original }()
+	var fetched []time.Time
+	Fetcher = func(start, end time.Time) ([]CalendarEvent, error) {
+		fetched = append(fetched, start, end)
+		return []CalendarEvent{{Title: start.Format("Jan 2")}}, nil
+	}
+
+	january := time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)
+	m := MonthModel{Month: january, Selected: time.Date(2026, 1, 31, 0, 0, 0, 0, time.UTC), loader: loader{Request: 1}}
+
+	updated, cmd := m.Update(tea.KeyPressMsg{Code: tea.KeyRight})
+	m = updated.(MonthModel)
+	if !m.Month.Equal(january.AddDate(0, 1, 0)) || !m.Loading || cmd == nil {
+		t.Fatalf("Expected moving past the last day to load February, got %v", m.Month)
+	}
+	updated, _ = m.Update(m.fetchCmd(m.Request)())
+	m = updated.(MonthModel)
+	if m.Loading || m.Events[0].Title != "Jan 26" {
+		t.Errorf("Expected the events from the grid start, got %+v", m.Events)
+	}
+	if want := time.Date(2026, 3, 2, 0, 0, 0, 0, time.UTC); !fetched[1].Equal(want) {
+		t.Errorf("Expected the fetch to end at %v, got %v", want, fetched[1])
+	}
+
+	m.Selected = time.Date(2026, 3, 31, 0, 0, 0, 0, time.UTC)
+	m.Month = time.Date(2026, 3, 1, 0, 0, 0, 0, time.UTC)
+	updated, _ = m.Update(tea.KeyPressMsg{Code: ']', Text: "]"})
+	m = updated.(MonthModel)
+	if want := time.Date(2026, 4, 30, 0, 0, 0, 0, time.UTC); !m.Selected.Equal(want) {
+		t.Errorf("Expected the day to be clamped to %v, got %v", want, m.Selected)
+	}
+}
+
+// spinnerTicks counts the spinner ticks started by cmd.
+func spinnerTicks(cmd tea.Cmd) int {
+	if cmd == nil {
+		return 0
+	}
+	switch msg := cmd().(type) {
+	case tea.BatchMsg:
+		ticks := 0
+		for _, c := range msg {
+			ticks += spinnerTicks(c)
+		}
+		return ticks
+	case spinnerMsg:
+		return 1
+	}
+	return 0
+}
+
+func TestMonthSharesSpinner(t *testing.T) {
+	original := Fetcher
+	defer func() { Fetcher = original }()
+	Fetcher = func(start, end time.Time) ([]CalendarEvent, error) { return nil, nil }
+
+	m := MonthModel{Month: time.Date(2026, 2, 1, 0, 0, 0, 0, time.UTC), Selected: time.Date(2026, 2, 4, 0, 0, 0, 0, time.UTC), loader: newLoader()}
+
+	updated, cmd := m.Update(tea.KeyPressMsg{Code: tea.KeyEnter})
+	m = updated.(MonthModel)
+	if ticks := spinnerTicks(cmd); ticks != 0 {
+		t.Errorf("Expected the grid to use the month's spinner, got %d new ticks", ticks)
+	}
+
+	updated, cmd = m.Update(spinnerMsg{})
+	m = updated.(MonthModel)
+	if m.Spinner != 1 || m.Grid.Spinner != 1 || spinnerTicks(cmd) != 1 {
+		t.Errorf("Expected one tick to advance both spinners, got %d and %d", m.Spinner, m.Grid.Spinner)
+	}
+
+	updated, cmd = m.Update(tea.KeyPressMsg{Code: 'm', Text: "m"})
+	m = updated.(MonthModel)
+	if ticks := spinnerTicks(cmd); ticks != 0 {
+		t.Errorf("Expected going back to the month to keep its spinner, got %d new ticks", ticks)
+	}
+
+	updated, _ = m.Update(m.fetchCmd(m.Request)())
+	m = updated.(MonthModel)
+	if _, cmd = m.Update(spinnerMsg{}); cmd != nil {
+		t.Error("Expected the spinner to stop once nothing is loading")
+	}
+	if _, cmd = m.Update(tea.KeyPressMsg{Code: tea.KeyEnter}); spinnerTicks(cmd) != 1 {
+		t.Error("Expected a grid opened while idle to start the spinner")
+	}
+}
+
+func TestMonthOpensGrid(t *testing.T) {
+	original := Fetcher
+	defer func() { Fetcher = original }()
+	Fetcher = func(start, end time.Time) ([]CalendarEvent, error) { return nil, nil }
+
+	wednesday := time.Date(2026, 2, 4, 0, 0, 0, 0, time.UTC)
+	m := MonthModel{Month: time.Date(2026, 2, 1, 0, 0, 0, 0, time.UTC), Selected: wednesday, Width: 200, Height: 40}
+
+	tests := []struct {
+		name        string
+		key         tea.KeyPressMsg
+		wantStart   time.Time
+		wantColumns int
+	}{
+		{"day", tea.KeyPressMsg{Code: tea.KeyEnter}, wednesday, 1},
+		{"week", tea.KeyPressMsg{Code: 'w', Text: "w"}, time.Date(2026, 2, 2, 0, 0, 0, 0, time.UTC), 7},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			updated, cmd := m.Update(tt.key)
+			opened := updated.(MonthModel)
+			if opened.Grid == nil || cmd == nil {
+				t.Fatal("Expected a grid to be opened and fetched")
+			}
+			if !opened.Grid.StartDate.Equal(tt.wantStart) || opened.Grid.ColumnCount != tt.wantColumns {
+				t.Errorf("Expected %d columns from %v, got %d from %v", tt.wantColumns, tt.wantStart, opened.Grid.ColumnCount, opened.Grid.StartDate)
+			}
+			if !strings.Contains(opened.View().Content, "m: Month") {
+				t.Error("Expected the grid footer to offer going back to the month")
+			}
+
+			updated, _ = opened.Update(tea.KeyPressMsg{Code: 'm', Text: "m"})
+			if back := updated.(MonthModel); back.Grid != nil {
+				t.Error("Expected m to close the grid")
+			}
+		})
+	}
+}
@@ -4,7 +4,6 @@ import (
 	"fmt"
 	"image/color"
 	"log/slog"
-	"os"
 	"sort"
 	"time"
 
@@ -13,21 +12,33 @@ import (
 	"github.com/kahnwong/gcal-tui/internal/utils"
 )
 
-// GetNextMeeting fetches all events and returns the next upcoming event
-func GetNextMeeting() (*CalendarEvent, error) {
-	now := utils.GetNowLocalAdjusted()
+// GetNextMeeting fetches all events and returns the next upcoming event.
+// Calendars that fail to load are skipped and named in warning, see
+// FetchWarning.
+func GetNextMeeting() (next *CalendarEvent, warning string, err error) {
+	now := utils.Now()
 
 	// Fetch events starting from now for the next week
-	weekStart := now.Truncate(24 * time.Hour)
-	allEvents, err := FetchAllEvents(weekStart)
-	if err != nil {
-		return nil, fmt.Errorf("failed to fetch events: %w", err)
+	weekStart := utils.StartOfDay(now)
+	allEvents, fetchErr := Fetcher(weekStart, weekStart.AddDate(0, 0, 7))
+	next, err = FindNextMeeting(allEvents, now)
+	if err != nil && fetchErr != nil {
+		// finding nothing may just mean the calendars could not be loaded
+		return nil, "", fmt.Errorf("failed to fetch events: %w", fetchErr)
 	}
+	return next, FetchWarning(fetchErr), err
+}
 
-	// Filter out past events and the "CURRENT TIME" marker
+// FindNextMeeting returns the first event starting after now.
+func FindNextMeeting(allEvents []CalendarEvent, now time.Time) (*CalendarEvent, error) {
+	// Filter out past, all-day, hidden or dimmed events, and out of office and
+	// working location entries
 	var upcomingEvents []CalendarEvent
 	for _, event := range allEvents {
-		if event.StartTime.After(now) && event.Title != "CURRENT TIME" {
+		if event.EventType == EventTypeOutOfOffice || event.EventType == EventTypeWorkingLocation {
+			continue
+		}
+		if event.StartTime.After(now) && !event.AllDay && !event.Hidden() && !event.Dimmed() {
 			upcomingEvents = append(upcomingEvents, event)
 		}
 	}
@@ -46,7 +57,7 @@ func GetNextMeeting() (*CalendarEvent, error) {
 
 // FormatTimeUntil returns a human-readable string showing time remaining until the event
 func FormatTimeUntil(eventTime time.Time) string {
-	now := utils.GetNowLocalAdjusted()
+	now := utils.Now()
 	duration := eventTime.Sub(now)
 
 	if duration < 0 {
@@ -80,7 +91,7 @@ func FormatTimeUntil(eventTime time.Time) string {
 
 // GetTimeColor returns the appropriate color based on time remaining until event
 func GetTimeColor(eventTime time.Time) color.Color {
-	now := utils.GetNowLocalAdjusted()
+	now := utils.Now()
 	duration := eventTime.Sub(now)
 
 	// Convert to minutes for easier comparison
@@ -97,7 +108,7 @@ func GetTimeColor(eventTime time.Time) color.Color {
 
 // DisplayNextMeeting shows the next meeting information with styled TUI
 func DisplayNextMeeting() {
-	nextEvent, err := GetNextMeeting()
+	nextEvent, _, err := GetNextMeeting()
 	if err != nil {
 		errorStyle := lipgloss.NewStyle().
 			Foreground(lipgloss.Color("#FF0000")).
@@ -149,9 +160,37 @@ func DisplayNextMeeting() {
 
 // NextMeetingModel represents the TUI model for the next meeting display
 type NextMeetingModel struct {
-	nextEvent  *CalendarEvent
-	err        error
-	lastUpdate time.Time
+	nextEvent *CalendarEvent
+	err       error
+	status    string
+
+	loader
+}
+
+// nextMeetingMsg carries the result of a fetch started by
+// NextMeetingModel.load.
+type nextMeetingMsg struct {
+	request   int
+	nextEvent *CalendarEvent
+	warning   string
+	err       error
+	at        time.Time
+}
+
+// fetchNextMeeting returns a command that looks up the next meeting in the
+// background and reports back with a nextMeetingMsg.
+func fetchNextMeeting(request int) tea.Cmd {
+	return func() tea.Msg {
+		nextEvent, warning, err := GetNextMeeting()
+		return nextMeetingMsg{request: request, nextEvent: nextEvent, warning: warning, err: err, at: utils.Now()}
+	}
+}
+
+// load starts looking up the next meeting, keeping the current one on screen
+// until the response arrives.
+func (m NextMeetingModel) load() (NextMeetingModel, tea.Cmd) {
+	request, spin := m.begin(m.Loading)
+	return m, tea.Batch(fetchNextMeeting(request), spin)
 }
 
 // tickMsg is sent every minute to update the display
@@ -164,23 +203,15 @@ func doTick() tea.Cmd {
 	})
 }
 
-// NewNextMeetingModel creates a new next meeting model
+// NewNextMeetingModel creates a new next meeting model. The meeting is looked
+// up once the program starts, see Init.
 func NewNextMeetingModel() NextMeetingModel {
-	nextEvent, err := GetNextMeeting()
-	if err != nil {
-		slog.Error("Error fetching next meeting", "error", err)
-		os.Exit(1)
-	}
-	return NextMeetingModel{
-		nextEvent:  nextEvent,
-		err:        nil,
-		lastUpdate: time.Now(),
-	}
+	return NextMeetingModel{loader: newLoader()}
 }
 
-// Init initializes the next meeting model and starts the ticker
+// Init starts the first lookup and the ticker
 func (m NextMeetingModel) Init() tea.Cmd {
-	return doTick()
+	return tea.Batch(fetchNextMeeting(m.Request), tickSpinner(), doTick())
 }
 
 // Update handles messages and updates the model
@@ -190,19 +221,32 @@ func (m NextMeetingModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 		switch msg.String() {
 		case "ctrl+c", "q":
 			return m, tea.Quit
+		case "j":
+			cmd, status := join(m.nextEvent)
+			m.status = status
+			return m, cmd
 		}
+	case joinMsg:
+		m.status = msg.Status()
 	case tickMsg:
 		// Update the next meeting data every minute
-		nextEvent, err := GetNextMeeting()
-		if err != nil {
-			// Print error to stdout and quit
-			fmt.Println(err)
-			return m, tea.Quit
+		var cmd tea.Cmd
+		m, cmd = m.load()
+		return m, tea.Batch(cmd, doTick()) // Schedule next tick
+	case nextMeetingMsg:
+		if msg.request != m.Request {
+			return m, nil
 		}
-		m.nextEvent = nextEvent
-		m.err = nil
-		m.lastUpdate = time.Time(msg)
-		return m, doTick() // Schedule next tick
+		if msg.err != nil {
+			slog.Error("Error fetching next meeting", "error", msg.err)
+		}
+		m.nextEvent = msg.nextEvent
+		m.err = msg.err
+		m.Warning = msg.warning
+		m.Updated = msg.at
+		m.Loading = false
+	case spinnerMsg:
+		return m, m.tick()
 	}
 	return m, nil
 }
@@ -212,6 +256,14 @@ func (m NextMeetingModel) View() tea.View {
 	v := tea.NewView("")
 	v.AltScreen = true
 
+	if m.nextEvent == nil && m.err == nil && m.Loading {
+		loadingStyle := LoadingStyle.
+			Align(lipgloss.Center).
+			Padding(2)
+		v.SetContent(loadingStyle.Render(spinnerFrame(m.Spinner) + " Looking up the next meeting..."))
+		return v
+	}
+
 	if m.err != nil {
 		errorStyle := lipgloss.NewStyle().
 			Foreground(lipgloss.Color("#FF0000")).
@@ -271,11 +323,21 @@ func (m NextMeetingModel) View() tea.View {
 	title := titleStyle.Render("📅 " + m.nextEvent.Title)
 	timeRemaining := timeStyle.Render("⏰ " + timeUntil)
 
-	startTime := detailsStyle.Render("Starts: " + m.nextEvent.StartTime.Add(time.Hour*time.Duration(-7)).Format("Monday, January 2, 2006 at 3:04 PM"))
-	lastUpdated := lastUpdatedStyle.Render("Last updated: " + m.lastUpdate.Format("3:04:05 PM"))
-	footer := detailsStyle.Render("Press 'q' or Ctrl+C to quit")
+	startTime := detailsStyle.Render("Starts: " + m.nextEvent.StartTime.Format("Monday, January 2, 2006 at 3:04 PM"))
+	updated := "Last updated: " + m.Updated.Format("3:04:05 PM")
+	if m.Loading {
+		updated = spinnerFrame(m.Spinner) + " Updating..."
+	}
+	lastUpdated := lastUpdatedStyle.Render(updated)
+	footer := detailsStyle.Render("Press 'j' to join, 'q' or Ctrl+C to quit")
 
 	content := lipgloss.JoinVertical(lipgloss.Center, title, timeRemaining, startTime, lastUpdated, footer)
+	if m.status != "" {
+		content = lipgloss.JoinVertical(lipgloss.Center, content, lastUpdatedStyle.Render(m.status))
+	}
+	if m.Warning != "" {
+		content = lipgloss.JoinVertical(lipgloss.Center, content, WarningStyle.Align(lipgloss.Center).Width(60).Render(m.Warning))
+	}
 	display := containerStyle.Render(content)
 
 	v.SetContent(display)
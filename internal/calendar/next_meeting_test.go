@@ -2,6 +2,7 @@ package calendar
 
 import (
 	"image/color"
+	"strings"
 	"testing"
 	"time"
 
@@ -138,3 +139,49 @@ func TestGetTimeColor(t *testing.T) {
 		})
 	}
 }
+
+func TestFindNextMeeting(t *testing.T) {
+	now := time.Date(2026, 1, 31, 10, 0, 0, 0, time.UTC)
+	events := []CalendarEvent{
+		{Title: "Past", StartTime: now.Add(-time.Hour), EndTime: now},
+		{Title: "Later", StartTime: now.Add(3 * time.Hour), EndTime: now.Add(4 * time.Hour)},
+		{Title: "Declined", StartTime: now.Add(time.Hour), EndTime: now.Add(2 * time.Hour), Display: "dim"},
+		{Title: "Out of office", EventType: EventTypeOutOfOffice, StartTime: now.Add(30 * time.Minute), EndTime: now.Add(8 * time.Hour)},
+		{Title: "Holiday", AllDay: true, StartTime: now.Add(14 * time.Hour), EndTime: now.Add(38 * time.Hour)},
+		{Title: "Next", StartTime: now.Add(2 * time.Hour), EndTime: now.Add(3 * time.Hour)},
+	}
+
+	next, err := FindNextMeeting(events, now)
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if next.Title != "Next" {
+		t.Errorf("Expected 'Next', got %q", next.Title)
+	}
+
+	if _, err := FindNextMeeting(events[:1], now); err == nil {
+		t.Error("Expected an error when nothing is upcoming")
+	}
+}
+
+func TestNextMeetingModelLoading(t *testing.T) {
+	m := NewNextMeetingModel()
+	if !m.Loading {
+		t.Fatal("Expected the model to start loading")
+	}
+	if content := m.View().Content; !strings.Contains(content, "Looking up the next meeting") {
+		t.Errorf("Expected a loading message, got:\n%s", content)
+	}
+
+	event := &CalendarEvent{Title: "Standup", StartTime: time.Now().Add(time.Hour)}
+	updated, _ := m.Update(nextMeetingMsg{request: m.Request - 1, nextEvent: &CalendarEvent{Title: "Stale"}})
+	if updated.(NextMeetingModel).nextEvent != nil {
+		t.Error("Expected a stale response to be dropped")
+	}
+
+	updated, _ = m.Update(nextMeetingMsg{request: m.Request, nextEvent: event})
+	m = updated.(NextMeetingModel)
+	if m.Loading || m.nextEvent != event {
+		t.Errorf("Expected the next meeting to be Standup, got %+v (loading %v)", m.nextEvent, m.Loading)
+	}
+}
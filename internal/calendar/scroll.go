@@ -0,0 +1,143 @@
+package calendar
+
+import (
+	"time"
+
+	"charm.land/lipgloss/v2"
+	"github.com/kahnwong/gcal-tui/configs"
+	"github.com/kahnwong/gcal-tui/internal/utils"
+)
+
+// SlotLength is the time covered by one row of the grid in new models: 15,
+// 30 or 60 minutes.
+var SlotLength = configs.DefaultSlotMinutes * time.Minute
+
+// slot returns the time covered by one row of the grid.
+func (m Model) slot() time.Duration {
+	if m.Slot <= 0 {
+		return configs.DefaultSlotMinutes * time.Minute
+	}
+	return m.Slot
+}
+
+// defaultViewportRows is the grid height used until the terminal size is known.
+const defaultViewportRows = 32
+
+// defaultTopHour is scrolled to when neither the current time nor any event
+// is in view.
+const defaultTopHour = 8
+
+// dayHours returns the configured range of hours covered by the grid.
+func dayHours() configs.HoursConfig {
+	var hours configs.HoursConfig
+	if configs.AppConfig != nil {
+		hours = configs.AppConfig.Hours
+	}
+	return hours.Merge(configs.DefaultHours)
+}
+
+// hours returns the range of hours covered by the grid, the whole day when
+// the model has none.
+func (m Model) hours() (start, end int) {
+	if m.EndHour <= m.StartHour {
+		return 0, 24
+	}
+	return m.StartHour, m.EndHour
+}
+
+// slotCount returns the number of rows in the whole grid.
+func (m Model) slotCount() int {
+	start, end := m.hours()
+	return int(time.Duration(end-start) * time.Hour / m.slot())
+}
+
+// slotTime returns the start of the given slot on day.
+func (m Model) slotTime(day time.Time, slot int) time.Time {
+	start, _ := m.hours()
+	minutes := start*60 + slot*int(m.slot()/time.Minute)
+	return utils.AtTimeIn(day, minutes/60, minutes%60, m.location())
+}
+
+// slotAt returns the slot holding the time of day of t, which is outside the
+// grid for times before or after the configured hours.
+func (m Model) slotAt(t time.Time) int {
+	start, _ := m.hours()
+	t = t.In(m.location())
+	minutes := t.Hour()*60 + t.Minute() - start*60
+	return minutes / int(m.slot()/time.Minute)
+}
+
+// viewportRows returns how many slots fit on screen, and whether the grid
+// scrolls. A scrolling grid keeps two rows for the hidden event counts.
+func (m Model) viewportRows() (rows int, scrolling bool) {
+	total := m.slotCount()
+	available := defaultViewportRows
+	if m.Height > 0 {
+		header := 1 + len(m.allDayRows())
+		available = m.Height - BorderStyle.GetVerticalFrameSize() - header - lipgloss.Height(m.footer())
+	}
+	if available >= total {
+		return total, false
+	}
+	return max(available-2, 1), true
+}
+
+// scrollTo moves the viewport to start at slot, keeping it within the grid.
+func (m Model) scrollTo(slot int) Model {
+	rows, _ := m.viewportRows()
+	m.Scroll = max(0, min(slot, m.slotCount()-rows))
+	return m
+}
+
+// autoScroll brings the current time into view when today is shown, or else
+// the earliest event of the visible days.
+func (m Model) autoScroll() Model {
+	now := m.now()
+	if index := dayIndex(m.StartDate, now); index >= 0 && index < m.ColumnCount {
+		// keep an hour of context above the now-line
+		return m.scrollTo(m.slotAt(now) - int(time.Hour/m.slot()))
+	}
+
+	first := -1
+	for d := range m.ColumnCount {
+		dayStart := m.StartDate.AddDate(0, 0, d)
+		for _, e := range m.Events {
+			if inBanner(e) || e.Hidden() || isBackground(e) {
+				continue
+			}
+			if segStart, _, ok := clipToDay(e, dayStart); ok {
+				if slot := m.slotAt(segStart); first < 0 || slot < first {
+					first = slot
+				}
+			}
+		}
+	}
+	if first >= 0 {
+		return m.scrollTo(first)
+	}
+
+	start, _ := m.hours()
+	return m.scrollTo(m.slotAt(utils.AtTimeIn(m.StartDate, max(defaultTopHour, start), 0, m.location())))
+}
+
+// hiddenEvents counts the events of the day starting at dayStart that lie
+// above and below the viewport.
+func (m Model) hiddenEvents(dayStart time.Time, rows int) (above, below int) {
+	top := m.slotTime(dayStart, m.Scroll)
+	bottom := m.slotTime(dayStart, m.Scroll+rows)
+	for _, e := range m.Events {
+		if inBanner(e) || e.Hidden() || isBackground(e) {
+			continue
+		}
+		segStart, segEnd, ok := clipToDay(e, dayStart)
+		if !ok {
+			continue
+		}
+		if !segEnd.After(top) {
+			above++
+		} else if !segStart.Before(bottom) {
+			below++
+		}
+	}
+	return above, below
+}
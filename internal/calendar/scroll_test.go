@@ -0,0 +1,102 @@
+package calendar
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	tea "charm.land/bubbletea/v2"
+	"github.com/kahnwong/gcal-tui/internal/utils"
+)
+
+func TestAutoScroll(t *testing.T) {
+	original := utils.Location
+	utils.Location = time.UTC
+	defer func() { utils.Location = original }()
+
+	monday := time.Date(2026, 2, 2, 0, 0, 0, 0, time.UTC)
+	early := CalendarEvent{Title: "Flight", StartTime: monday.AddDate(0, 0, 9).Add(6 * time.Hour), EndTime: monday.AddDate(0, 0, 9).Add(8 * time.Hour)}
+	late := CalendarEvent{Title: "Dinner", StartTime: monday.AddDate(0, 0, 10).Add(19 * time.Hour), EndTime: monday.AddDate(0, 0, 10).Add(21 * time.Hour)}
+
+	tests := []struct {
+		name   string
+		start  time.Time
+		events []CalendarEvent
+		want   int
+	}{
+		{"an hour before now", monday, nil, 18},
+		{"first event", monday.AddDate(0, 0, 7), []CalendarEvent{late, early}, 12},
+		{"nothing to show", monday.AddDate(0, 0, 7), nil, 16},
+		{"clamped to the end", monday.AddDate(0, 0, 7), []CalendarEvent{late}, 48 - 30},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			m := Model{StartDate: tt.start, ColumnCount: 7, ColWidth: 20, Events: tt.events, Now: monday.Add(10 * time.Hour)}
+			if m = m.autoScroll(); m.Scroll != tt.want {
+				t.Errorf("Scroll = %d, want %d", m.Scroll, tt.want)
+			}
+		})
+	}
+}
+
+func TestScrollKeys(t *testing.T) {
+	monday := time.Date(2026, 2, 2, 0, 0, 0, 0, time.UTC)
+	m := Model{StartDate: monday.AddDate(0, 0, 7), ColumnCount: 7, ColWidth: 20, Now: monday}
+	updated, _ := m.Update(tea.WindowSizeMsg{Width: 200, Height: 30})
+	m = updated.(Model)
+
+	rows, scrolling := m.viewportRows()
+	if !scrolling || rows >= 30 {
+		t.Fatalf("Expected a viewport smaller than the terminal, got %d rows", rows)
+	}
+	if lines := strings.Count(m.View().Content, "\n") + 1; lines > 30 {
+		t.Errorf("Expected the view to fit in 30 lines, got %d", lines)
+	}
+
+	steps := []struct {
+		key  tea.KeyPressMsg
+		want int
+	}{
+		{tea.KeyPressMsg{Code: tea.KeyDown}, m.Scroll + 1},
+		{tea.KeyPressMsg{Code: tea.KeyUp}, m.Scroll},
+		{tea.KeyPressMsg{Code: 'J', Text: "J"}, m.Scroll + 1},
+		{tea.KeyPressMsg{Code: 'K', Text: "K"}, m.Scroll},
+		{tea.KeyPressMsg{Code: tea.KeyPgDown}, m.slotCount() - rows},
+		{tea.KeyPressMsg{Code: tea.KeyPgUp}, m.slotCount() - 2*rows},
+		{tea.KeyPressMsg{Code: tea.KeyPgUp}, 0},
+		{tea.KeyPressMsg{Code: tea.KeyUp}, 0},
+	}
+	for _, step := range steps {
+		updated, _ = m.Update(step.key)
+		m = updated.(Model)
+		if m.Scroll != step.want {
+			t.Fatalf("After %s: Scroll = %d, want %d", step.key, m.Scroll, step.want)
+		}
+	}
+	if !m.Scrolled {
+		t.Error("Expected scrolling to turn auto-scroll off")
+	}
+}
+
+func TestHiddenEvents(t *testing.T) {
+	monday := time.Date(2026, 2, 2, 0, 0, 0, 0, time.UTC)
+	at := func(hour int) time.Time { return monday.Add(time.Duration(hour) * time.Hour) }
+	m := Model{
+		StartDate:   monday,
+		ColumnCount: 1,
+		ColWidth:    20,
+		Scroll:      16, // 08:00
+		Events: []CalendarEvent{
+			{Title: "Standup", StartTime: at(7), EndTime: at(8)},
+			{Title: "Review", StartTime: at(9), EndTime: at(10)},
+			{Title: "Spans the top", StartTime: at(7), EndTime: at(9)},
+			{Title: "Late", StartTime: at(20), EndTime: at(21)},
+			{Title: "Holiday", StartTime: monday, EndTime: monday.AddDate(0, 0, 1), AllDay: true},
+		},
+	}
+
+	above, below := m.hiddenEvents(monday, 20) // 08:00 to 18:00
+	if above != 1 || below != 1 {
+		t.Errorf("hiddenEvents() = (%d, %d), want (1, 1)", above, below)
+	}
+}
@@ -3,8 +3,8 @@ package calendar
 import (
 	"fmt"
 	"image/color"
-	"log/slog"
-	"os"
+	"slices"
+	"sort"
 	"strings"
 	"time"
 
@@ -15,12 +15,34 @@ import (
 
 type Model struct {
 	Events      []CalendarEvent
-	StartDate   time.Time // Starting date (Monday for week view, specific date for today view)
-	ColumnCount int       // Number of columns (1 for today, 7 for week)
-	ColWidth    int       // Width of each column
+	StartDate   time.Time        // Starting date (Monday for week view, specific date for today view)
+	ColumnCount int              // Number of columns (1 for today, 7 for week)
+	Period      int              // Days moved by left/right, ColumnCount when unset
+	ColWidth    int              // Width of each column, fitted to Width once it is known
+	Width       int              // Terminal width, from tea.WindowSizeMsg
+	Height      int              // Terminal height, from tea.WindowSizeMsg
+	Zones       []*time.Location // Time zones in the time gutter, the first one is primary
+	Status      string           // Message from the last action, shown above the footer
+	Now         time.Time        // Current time for the now-line, advanced every minute
+	StartHour   int              // First hour covered by the grid
+	Slot        time.Duration    // Time covered by one grid row, 30 minutes when unset
+	EndHour     int              // Hour the grid ends at, exclusive
+	Scroll      int              // First slot in the viewport
+	Scrolled    bool             // The user scrolled since the range changed, so auto-scroll is off
+	Cursor      string           // Selected event, see eventKey
+	Detail      bool             // The detail pane for the selected event is open
+	InMonth     bool             // Opened from the month view, which "m" goes back to
+
+	loader
 }
 
+// GetColorValue maps a configured color name or a "#rrggbb" hex value, as
+// returned by the Google Calendar API, to a color.
 func GetColorValue(name string) color.Color {
+	if strings.HasPrefix(name, "#") {
+		return lipgloss.Color(name)
+	}
+
 	switch name {
 	case "aqua":
 		return lipgloss.Color("#00FFFF")
@@ -35,118 +57,641 @@ func GetColorValue(name string) color.Color {
 	}
 }
 
+// GetTextColorValue returns the foreground for text drawn on an event,
+// defaulting to black when Google did not provide one.
+func GetTextColorValue(name string) color.Color {
+	if name == "" {
+		return lipgloss.Color("#000000")
+	}
+	return GetColorValue(name)
+}
+
 // Styles for rendering
 var (
 	EventStyle       = lipgloss.NewStyle().Foreground(lipgloss.Color("#000000")).Bold(true)
 	EventStyleActive = lipgloss.NewStyle().Foreground(lipgloss.Color("#FF0000")).Bold(true)
+	EventStyleDimmed = lipgloss.NewStyle().Background(lipgloss.Color("#444"))
+	EventStyleFocus  = lipgloss.NewStyle().Background(lipgloss.Color("#1A1A2E")).Italic(true).Bold(true)
+	OutOfOfficeStyle = lipgloss.NewStyle().Background(lipgloss.Color("#2A2A2A")).Foreground(lipgloss.Color("#777"))
 	EmptyStyle       = lipgloss.NewStyle().Background(lipgloss.Color("#222")).Foreground(lipgloss.Color("#888"))
 	HeaderStyle      = lipgloss.NewStyle().
 				Background(lipgloss.Color("#FFF")).
 				Foreground(lipgloss.Color("#000")).
 				Align(lipgloss.Center).
 				Bold(true)
-	TimeLabelStyle = lipgloss.NewStyle().Foreground(lipgloss.Color("#0FF"))
-	BorderStyle    = lipgloss.NewStyle().Border(lipgloss.HiddenBorder()).Padding(0, 1)
-	SeparatorStyle = lipgloss.NewStyle().Foreground(lipgloss.Color("#555"))
+	TimeLabelStyle          = lipgloss.NewStyle().Foreground(lipgloss.Color("#0FF"))
+	SecondaryTimeLabelStyle = lipgloss.NewStyle().Foreground(lipgloss.Color("#088"))
+	BorderStyle             = lipgloss.NewStyle().Border(lipgloss.HiddenBorder()).Padding(0, 1)
+	SeparatorStyle          = lipgloss.NewStyle().Foreground(lipgloss.Color("#555"))
+	WarningStyle            = lipgloss.NewStyle().Foreground(lipgloss.Color("#FFA500"))
+	LoadingStyle            = lipgloss.NewStyle().Foreground(lipgloss.Color("#0FF"))
+	ScrollHintStyle         = lipgloss.NewStyle().Foreground(lipgloss.Color("#888")).Align(lipgloss.Center)
+	OverflowStyle           = lipgloss.NewStyle().Background(lipgloss.Color("#444")).Foreground(lipgloss.Color("#FFF")).Bold(true)
+	NowLineStyle            = lipgloss.NewStyle().Background(lipgloss.Color("#222")).Foreground(lipgloss.Color("#FF0000")).Bold(true)
 )
 
-// NewModel creates a new calendar model with specified column count and width
-func NewModel(columnCount int, colWidth int) Model {
-	now := time.Now()
-	var startDate time.Time
+// Layout sizes
+const (
+	gutterWidth     = 7 // time labels in the primary zone
+	zoneGutterWidth = 8 // time labels in each secondary zone
+	MinColWidth     = 4 // columns never shrink below this, the view overflows instead
+)
 
-	if columnCount == 7 {
-		// Week view: find Monday of current week
-		offset := int(now.Weekday()) - 1
-		if offset < 0 {
-			offset = 6 // Sunday
-		}
-		startDate = now.AddDate(0, 0, -offset).Truncate(24 * time.Hour)
-	} else {
-		// Today view: use current date
-		startDate = now.Truncate(24 * time.Hour)
-	}
+// NewDaysModel creates a rolling view of the given number of days starting
+// today, moving by that many days. colWidth is used until the terminal size
+// is known, see layout.
+func NewDaysModel(days int, colWidth int) Model {
+	return NewModelAt(utils.StartOfDay(utils.Now()), days, colWidth)
+}
 
-	events, err := FetchAllEvents(startDate)
-	if err != nil {
-		slog.Error("Error fetching events", "error", err)
-		os.Exit(1)
+// NewWeekModel creates a view of the current week, starting on WeekStart.
+// Weekends are left out when HideWeekends is set.
+func NewWeekModel(colWidth int) Model {
+	m := NewModelAt(startOfWeek(utils.Now()), 7, colWidth)
+	if HideWeekends {
+		m = m.withWeekends(false)
 	}
-	return Model{
-		Events:      events,
+	return m
+}
+
+// NewWorkWeekModel creates a view of the current week from Monday to Friday,
+// moving by whole weeks.
+func NewWorkWeekModel(colWidth int) Model {
+	return NewModelAt(startOfWeek(utils.Now()), 7, colWidth).withWeekends(false)
+}
+
+// NewModelAt creates a calendar model showing columnCount days from startDate.
+func NewModelAt(startDate time.Time, columnCount int, colWidth int) Model {
+	hours := dayHours()
+	m := Model{
 		StartDate:   startDate,
 		ColumnCount: columnCount,
 		ColWidth:    colWidth,
+		Zones:       append([]*time.Location{utils.Location}, utils.ExtraLocations...),
+		Now:         utils.Now(),
+		StartHour:   hours.Start,
+		EndHour:     hours.End,
+		Slot:        SlotLength,
+		loader:      newLoader(),
+	}
+	return m.autoScroll()
+}
+
+// period is the number of days moved by left/right.
+func (m Model) period() int {
+	if m.Period > 0 {
+		return m.Period
 	}
+	return max(m.ColumnCount, 1)
+}
+
+// load starts fetching the events for the visible range in the background.
+// Cached events for the range are shown right away while they are fetched
+// again, otherwise the current events stay on screen until the response
+// arrives.
+func (m Model) load() (Model, tea.Cmd) {
+	if events, ok := m.cached(m.StartDate, m.ColumnCount); ok {
+		m.Events = m.inZone(events)
+	}
+	if !m.Scrolled {
+		m = m.autoScroll()
+	}
+	cmd := m.fetch(m.StartDate, m.ColumnCount)
+	return m, cmd
+}
+
+// loaded shows a fetch result unless the user has navigated on since it was
+// requested, then prefetches the adjacent ranges.
+func (m Model) loaded(msg eventsMsg) (Model, tea.Cmd) {
+	if !m.receive(msg) {
+		return m, nil
+	}
+	m.Events = m.inZone(msg.events)
+	if !m.Scrolled {
+		m = m.autoScroll()
+	}
+	return m, m.prefetch(m.StartDate, m.ColumnCount, m.period())
 }
 
 // InitialModel creates a week view model (7 columns, 20 width) - for backward compatibility
 func InitialModel() Model {
-	return NewModel(7, 20)
+	return NewWeekModel(20)
 }
 
 // InitialTodayModel creates a today view model (1 column, 20 width) - for backward compatibility
 func InitialTodayModel() Model {
-	return NewModel(1, 20)
+	return NewDaysModel(1, 20)
 }
 
-func (m Model) Init() tea.Cmd { return nil }
+// nowMsg is sent at the start of every minute to move the now-line.
+type nowMsg time.Time
+
+// tickNow returns a command that sends a nowMsg on the next full minute.
+func tickNow() tea.Cmd {
+	return tea.Every(time.Minute, func(t time.Time) tea.Msg {
+		return nowMsg(t)
+	})
+}
+
+func (m Model) Init() tea.Cmd {
+	return tea.Batch(tickNow(), tickRefresh(), fetchCmd(m.StartDate, m.ColumnCount, m.Request), tickSpinner())
+}
+
+// now returns the time the grid is drawn for, in the primary zone.
+func (m Model) now() time.Time {
+	return viewTime(m.Now, m.location())
+}
+
+// location returns the primary time zone, the first of Zones, which the grid
+// is laid out in. It is utils.Location until "z" swaps it.
+func (m Model) location() *time.Location {
+	if len(m.Zones) == 0 {
+		return utils.Location
+	}
+	return m.Zones[0]
+}
+
+// inZone returns a copy of events with their times in the primary zone, so
+// the slice shared with the cache is left as it is. All-day events keep their
+// dates.
+func (m Model) inZone(events []CalendarEvent) []CalendarEvent {
+	loc := m.location()
+	if loc == utils.Location {
+		return events // fetched events are already in it
+	}
+	converted := slices.Clone(events)
+	for i, e := range converted {
+		if e.AllDay {
+			converted[i].StartTime = sameDateIn(e.StartTime, loc)
+			converted[i].EndTime = sameDateIn(e.EndTime, loc)
+			continue
+		}
+		converted[i].StartTime = e.StartTime.In(loc)
+		converted[i].EndTime = e.EndTime.In(loc)
+	}
+	return converted
+}
+
+// sameDateIn returns midnight in loc of the date t has in its own zone.
+func sameDateIn(t time.Time, loc *time.Location) time.Time {
+	year, month, day := t.Date()
+	return time.Date(year, month, day, 0, 0, 0, 0, loc)
+}
 
 func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 	switch msg := msg.(type) {
+	case nowMsg:
+		m.Now = time.Time(msg).In(m.location())
+		if !m.Scrolled {
+			// follow the now-line
+			m = m.autoScroll()
+		}
+		return m, tickNow()
+	case tea.WindowSizeMsg:
+		m = m.layout(msg.Width, msg.Height)
+	case refreshMsg:
+		var cmd tea.Cmd
+		m, cmd = m.load()
+		return m, tea.Batch(cmd, tickRefresh())
+	case eventsMsg:
+		return m.loaded(msg)
+	case spinnerMsg:
+		return m, m.tick()
+	case joinMsg:
+		m.Status = msg.Status()
 	case tea.KeyPressMsg:
 		switch msg.String() {
 		case "ctrl+c", "q":
 			return m, tea.Quit
 		case "left":
-			if m.ColumnCount == 7 {
-				// Previous week
-				m.StartDate = m.StartDate.AddDate(0, 0, -7)
+			// Back by the length of the view
+			m.StartDate = m.StartDate.AddDate(0, 0, -m.period())
+			m.Scrolled, m.Cursor, m.Detail = false, "", false
+			return m.load()
+		case "right":
+			// Forward by the length of the view
+			m.StartDate = m.StartDate.AddDate(0, 0, m.period())
+			m.Scrolled, m.Cursor, m.Detail = false, "", false
+			return m.load()
+		case "tab":
+			m = m.moveCursor(1)
+		case "shift+tab":
+			m = m.moveCursor(-1)
+		case "enter":
+			m.Detail = !m.Detail && m.cursorEvent() != nil
+		case "esc":
+			if m.Detail {
+				m.Detail = false
 			} else {
-				// Previous day
-				m.StartDate = m.StartDate.AddDate(0, 0, -1)
+				m.Cursor = ""
 			}
-			events, err := FetchAllEvents(m.StartDate)
-			if err == nil {
-				m.Events = events
+		case "down":
+			// arrow keys move the cursor within its day, or else scroll
+			if moved, ok := m.moveCursorInDay(1); ok {
+				m = moved
+				break
 			}
-		case "right":
-			if m.ColumnCount == 7 {
-				// Next week
-				m.StartDate = m.StartDate.AddDate(0, 0, 7)
-			} else {
-				// Next day
-				m.StartDate = m.StartDate.AddDate(0, 0, 1)
+			m.Scrolled = true
+			m = m.scrollTo(m.Scroll + 1)
+		case "up":
+			if moved, ok := m.moveCursorInDay(-1); ok {
+				m = moved
+				break
 			}
-			events, err := FetchAllEvents(m.StartDate)
-			if err == nil {
-				m.Events = events
+			m.Scrolled = true
+			m = m.scrollTo(m.Scroll - 1)
+		case "J":
+			// scroll by a line even while the cursor is set
+			m.Scrolled = true
+			m = m.scrollTo(m.Scroll + 1)
+		case "K":
+			m.Scrolled = true
+			m = m.scrollTo(m.Scroll - 1)
+		case "pgdown":
+			rows, _ := m.viewportRows()
+			m.Scrolled = true
+			m = m.scrollTo(m.Scroll + rows)
+		case "pgup":
+			rows, _ := m.viewportRows()
+			m.Scrolled = true
+			m = m.scrollTo(m.Scroll - rows)
+		case "r":
+			return m.load()
+		case "j":
+			cmd, status := join(m.selectedEvent())
+			m.Status = status
+			return m, cmd
+		case "w":
+			if !m.isWeekView() {
+				break
 			}
+			m = m.withWeekends(m.ColumnCount != 7)
+			m.Scrolled, m.Cursor, m.Detail = false, "", false
+			return m.load()
+		case "z":
+			if len(m.Zones) < 2 {
+				break
+			}
+			m = m.swapZone()
+			return m.load()
 		}
 	}
 	return m, nil
 }
 
+// selectedEvent returns the event acted on by keys like "j": the one under
+// the cursor, the one in progress, or else the next one starting within the
+// visible range.
+func (m Model) selectedEvent() *CalendarEvent {
+	if e := m.cursorEvent(); e != nil {
+		return e
+	}
+
+	now := m.now()
+	end := m.StartDate.AddDate(0, 0, m.ColumnCount)
+
+	var selected *CalendarEvent
+	for i, e := range m.Events {
+		if e.AllDay || e.Hidden() || isBackground(e) || !e.EndTime.After(now) || !e.StartTime.Before(end) {
+			continue
+		}
+		if !e.StartTime.After(now) {
+			return &m.Events[i]
+		}
+		if selected == nil || e.StartTime.Before(selected.StartTime) {
+			selected = &m.Events[i]
+		}
+	}
+	return selected
+}
+
+// layout fits the columns to a terminal of the given size.
+func (m Model) layout(width, height int) Model {
+	m.Width, m.Height = width, height
+	gutter := gutterWidth + zoneGutterWidth*max(len(m.Zones)-1, 0)
+	separators := m.ColumnCount - 1
+	available := width - BorderStyle.GetHorizontalFrameSize() - gutter - separators
+	m.ColWidth = max(available/m.ColumnCount, MinColWidth)
+	if !m.Scrolled {
+		return m.autoScroll()
+	}
+	return m.scrollTo(m.Scroll)
+}
+
+// dayLabel returns the header for day, as long as the column allows:
+// "Monday 10/20" in single column views, then "Mon 10/20", "M 10/20", "M20"
+// and "M".
+func (m Model) dayLabel(day time.Time) string {
+	weekday := day.Weekday().String()
+	switch {
+	case m.ColumnCount == 1 && m.ColWidth >= 12:
+		return day.Format("Monday 01/02")
+	case m.ColWidth >= 9:
+		return day.Format("Mon 01/02")
+	case m.ColWidth >= 7:
+		return weekday[:1] + day.Format(" 01/02")
+	case m.ColWidth >= 3:
+		return fmt.Sprintf("%s%02d", weekday[:1], day.Day())
+	default:
+		return weekday[:1]
+	}
+}
+
+// swapZone makes the next time zone primary, keeping the same dates on screen.
+func (m Model) swapZone() Model {
+	m.Zones = append(m.Zones[1:len(m.Zones):len(m.Zones)], m.Zones[0])
+	m.StartDate = sameDateIn(m.StartDate, m.location())
+	m.Events = m.inZone(m.Events)
+	return m
+}
+
+// referenceDay is the day used to convert gutter times into the secondary
+// zones: today when it is visible, otherwise the first column. Offsets can
+// change within a week on DST transitions, so this is exact for that day.
+func (m Model) referenceDay() time.Time {
+	today := utils.StartOfDayIn(m.now(), m.location())
+	if index := dayIndex(m.StartDate, today); index >= 0 && index < m.ColumnCount {
+		return today
+	}
+	return m.StartDate
+}
+
+// gutter renders the time label columns: label in the primary zone, followed
+// by t converted into each secondary zone when withTimes is set.
+func (m Model) gutter(label string, t time.Time, withTimes bool) string {
+	parts := []string{TimeLabelStyle.Width(gutterWidth).Render(label)}
+	for _, zone := range m.Zones[min(1, len(m.Zones)):] {
+		zoneLabel := ""
+		if withTimes {
+			zoneLabel = formatZoneTime(t, zone)
+		}
+		parts = append(parts, SecondaryTimeLabelStyle.Width(zoneGutterWidth).Render(zoneLabel))
+	}
+	return strings.Join(parts, "")
+}
+
+// nowGutter renders the time label columns for the row holding the current
+// time, showing the exact time in every zone.
+func (m Model) nowGutter(now time.Time) string {
+	parts := []string{NowLineStyle.Width(gutterWidth).Render("▶" + now.Format("15:04"))}
+	for _, zone := range m.Zones[min(1, len(m.Zones)):] {
+		parts = append(parts, NowLineStyle.Width(zoneGutterWidth).Render(formatZoneTime(now, zone)))
+	}
+	return strings.Join(parts, "")
+}
+
+// zoneHeader renders the gutter in the header row, naming each zone.
+func (m Model) zoneHeader() string {
+	if len(m.Zones) < 2 {
+		return TimeLabelStyle.Width(gutterWidth).Render("")
+	}
+
+	t := m.referenceDay()
+	parts := []string{TimeLabelStyle.Width(gutterWidth).Render(zoneName(t, m.Zones[0]))}
+	for _, zone := range m.Zones[1:] {
+		parts = append(parts, SecondaryTimeLabelStyle.Width(zoneGutterWidth).Render(zoneName(t, zone)))
+	}
+	return strings.Join(parts, "")
+}
+
+// zoneName returns the abbreviation of zone at t, e.g. "CET" or "PDT".
+func zoneName(t time.Time, zone *time.Location) string {
+	name, _ := t.In(zone).Zone()
+	return name
+}
+
+// formatZoneTime formats t in zone as "15:04", suffixed with "+1" or "-1"
+// when that falls on a different date than t in its own zone.
+func formatZoneTime(t time.Time, zone *time.Location) string {
+	converted := t.In(zone)
+	label := converted.Format("15:04")
+
+	switch diff := dateDiff(t, converted); {
+	case diff > 0:
+		label += fmt.Sprintf("+%d", diff)
+	case diff < 0:
+		label += fmt.Sprintf("%d", diff)
+	}
+	return label
+}
+
+// dayColumns returns the first and last column an event covers, clipped to
+// the visible range. ok is false when the event is not visible at all.
+func (m Model) dayColumns(e CalendarEvent) (first, last int, ok bool) {
+	first = dayIndex(m.StartDate, e.StartTime)
+	last = dayIndex(m.StartDate, e.EndTime.Add(-time.Nanosecond)) // EndTime is exclusive
+	if last < 0 || first >= m.ColumnCount {
+		return 0, 0, false
+	}
+	return max(first, 0), min(last, m.ColumnCount-1), true
+}
+
+// eventStyle returns the style for an event block, greyed out when the event
+// is dimmed by the filters.
+func eventStyle(e CalendarEvent) lipgloss.Style {
+	if e.Dimmed() {
+		// declined and cancelled events are struck through, free ones only greyed
+		return EventStyleDimmed.Foreground(GetColorValue(e.Color)).Strikethrough(e.Declined() || e.Status == "cancelled")
+	}
+	if e.EventType == EventTypeFocusTime {
+		return EventStyleFocus.Foreground(GetColorValue(e.Color))
+	}
+	return EventStyle.Background(GetColorValue(e.Color)).Foreground(GetTextColorValue(e.TextColor))
+}
+
+// MultiDayThreshold is the duration beyond which a timed event is shown in the
+// all-day strip instead of the time grid. It is fixed rather than configurable:
+// anything up to a full day, such as a 24-hour on-call shift, still fits the
+// grid.
+const MultiDayThreshold = 24 * time.Hour
+
+// inBanner reports whether an event is drawn in the all-day strip.
+func inBanner(e CalendarEvent) bool {
+	if isBackground(e) {
+		return false
+	}
+	return e.AllDay || e.EndTime.Sub(e.StartTime) > MultiDayThreshold
+}
+
+// isBackground reports whether an event is drawn as part of the day rather
+// than as a block: out of office shading or a working location badge.
+func isBackground(e CalendarEvent) bool {
+	return e.EventType == EventTypeOutOfOffice || e.EventType == EventTypeWorkingLocation
+}
+
+// outOfOfficeAt returns the out of office event covering cellTime on the day
+// starting at dayStart, with the start of its part on that day.
+func (m Model) outOfOfficeAt(cellTime, dayStart time.Time) (CalendarEvent, time.Time, bool) {
+	for _, e := range m.Events {
+		if e.EventType != EventTypeOutOfOffice || e.Hidden() {
+			continue
+		}
+		segStart, segEnd, ok := clipToDay(e, dayStart)
+		if ok && !cellTime.Before(segStart) && cellTime.Before(segEnd) {
+			return e, segStart, true
+		}
+	}
+	return CalendarEvent{}, time.Time{}, false
+}
+
+// workingLocation returns the working location set for the given day, if any.
+func (m Model) workingLocation(day time.Time) string {
+	for _, e := range m.Events {
+		if e.EventType != EventTypeWorkingLocation || e.Hidden() || e.WorkingLocation == "" {
+			continue
+		}
+		if _, _, ok := clipToDay(e, day); ok {
+			return e.WorkingLocation
+		}
+	}
+	return ""
+}
+
+// hatch pads label to width with a diagonal hatch pattern.
+func hatch(label string, width int) string {
+	return label + strings.Repeat("╱", max(width-len([]rune(label)), 0))
+}
+
+// clipToDay returns the part of a timed event that falls on the day starting
+// at dayStart. ok is false when the event does not touch that day.
+func clipToDay(e CalendarEvent, dayStart time.Time) (start, end time.Time, ok bool) {
+	dayEnd := dayStart.AddDate(0, 0, 1)
+	if !e.StartTime.Before(dayEnd) || !e.EndTime.After(dayStart) {
+		return time.Time{}, time.Time{}, false
+	}
+
+	start, end = e.StartTime, e.EndTime
+	if start.Before(dayStart) {
+		start = dayStart
+	}
+	if end.After(dayEnd) {
+		end = dayEnd
+	}
+	return start, end, true
+}
+
+// eventsOn returns the events listed on the day starting at day: all-day and
+// multi-day events first, then timed ones by start time.
+func eventsOn(all []CalendarEvent, day time.Time) []CalendarEvent {
+	var events []CalendarEvent
+	for _, e := range all {
+		if e.Hidden() || e.EventType == EventTypeWorkingLocation {
+			continue
+		}
+		if _, _, ok := clipToDay(e, day); ok {
+			events = append(events, e)
+		}
+	}
+	sort.SliceStable(events, func(i, j int) bool {
+		if inBanner(events[i]) != inBanner(events[j]) {
+			return inBanner(events[i])
+		}
+		return events[i].StartTime.Before(events[j].StartTime)
+	})
+	return events
+}
+
+// segmentTitle labels the part of an event starting at segStart, marking
+// days after the first one as a continuation.
+func segmentTitle(e CalendarEvent, segStart time.Time) string {
+	if segStart.After(e.StartTime) {
+		return "cont. " + e.DisplayTitle()
+	}
+	return e.DisplayTitle()
+}
+
+// chunkTitle splits a title into pieces of at most width runes, one per slot.
+func chunkTitle(title string, width int) []string {
+	runes := []rune(title)
+	if width <= 0 {
+		return nil
+	}
+
+	var chunks []string
+	for i := 0; i < len(runes); i += width {
+		chunks = append(chunks, string(runes[i:min(i+width, len(runes))]))
+	}
+	return chunks
+}
+
+// allDayRows lays out the visible all-day and multi-day events into banner
+// rows, so that events sharing a row never cover the same column.
+func (m Model) allDayRows() [][]CalendarEvent {
+	var events []CalendarEvent
+	for _, e := range m.Events {
+		if _, _, ok := m.dayColumns(e); ok && inBanner(e) && !e.Hidden() {
+			events = append(events, e)
+		}
+	}
+	sort.SliceStable(events, func(i, j int) bool {
+		return events[i].StartTime.Before(events[j].StartTime)
+	})
+
+	var rows [][]CalendarEvent
+	var rowEnds []int // last occupied column per row
+	for _, e := range events {
+		first, last, _ := m.dayColumns(e)
+		placed := false
+		for i := range rows {
+			if rowEnds[i] < first {
+				rows[i] = append(rows[i], e)
+				rowEnds[i] = last
+				placed = true
+				break
+			}
+		}
+		if !placed {
+			rows = append(rows, []CalendarEvent{e})
+			rowEnds = append(rowEnds, last)
+		}
+	}
+	return rows
+}
+
+// dayIndex returns the number of calendar days from start's date to t's date
+// in start's location, negative when t is before start. Days are counted by
+// date rather than by 24h, since DST days are 23 or 25 hours long.
+func dayIndex(start, t time.Time) int {
+	return dateDiff(start, t.In(start.Location()))
+}
+
+// dateDiff returns the number of days between the wall-clock dates of a and
+// b, each in its own location.
+func dateDiff(a, b time.Time) int {
+	ay, am, ad := a.Date()
+	by, bm, bd := b.Date()
+	return int(time.Date(by, bm, bd, 0, 0, 0, 0, time.UTC).Sub(time.Date(ay, am, ad, 0, 0, 0, 0, time.UTC)).Hours() / 24)
+}
+
+// truncate shortens s to fit within width cells, marking the cut with "…".
+func truncate(s string, width int) string {
+	runes := []rune(s)
+	if len(runes) <= width {
+		return s
+	}
+	if width <= 1 {
+		return string(runes[:max(width, 0)])
+	}
+	return string(runes[:width-1]) + "…"
+}
+
 func (m Model) View() tea.View {
 	v := tea.NewView("")
 	v.AltScreen = true
 
-	// Time slots: 8am to 12am, 30-minute intervals
-	startHour, endHour := 8, 24
-	days := []string{"Mon", "Tue", "Wed", "Thu", "Fri", "Sat", "Sun"}
-
 	var headerParts []string
-	headerParts = append(headerParts, TimeLabelStyle.Width(7).Render("")) // time label column for alignment
+	headerParts = append(headerParts, m.zoneHeader()) // time label columns for alignment
 
 	for d := range m.ColumnCount {
 		dayDate := m.StartDate.AddDate(0, 0, d)
-		var dayLabel string
-		if m.ColumnCount == 1 {
-			dayLabel = dayDate.Format("Monday 01/02")
-		} else {
-			dayLabel = fmt.Sprintf("%s %02d/%02d", days[d], dayDate.Month(), dayDate.Day())
+		dayLabel := m.dayLabel(dayDate)
+		if location := m.workingLocation(dayDate); location != "" {
+			// working location badge, shortened to whatever room is left
+			if room := m.ColWidth - len([]rune(dayLabel)) - 2; room > 0 {
+				dayLabel += " ⌂" + truncate(location, room)
+			}
 		}
-		headerParts = append(headerParts, HeaderStyle.Width(m.ColWidth).Render(dayLabel))
+		headerParts = append(headerParts, headerStyle(dayDate).Width(m.ColWidth).Render(dayLabel))
 		if d < m.ColumnCount-1 {
 			headerParts = append(headerParts, SeparatorStyle.Width(1).Render("|"))
 		}
@@ -155,75 +700,204 @@ func (m Model) View() tea.View {
 	var tableRows []string
 	tableRows = append(tableRows, strings.Join(headerParts, ""))
 
-	// Time rows (30-minute intervals)
-	now := utils.GetNowLocalAdjusted()
-	for hour := startHour; hour < endHour; hour++ {
-		for min := 0; min < 60; min += 30 {
-			var timeLabel string
-			if min == 0 {
-				timeLabel = fmt.Sprintf("%02d:00", hour)
-			} else {
-				timeLabel = ""
-			}
+	if e := m.cursorEvent(); m.Detail && e != nil {
+		// the detail pane takes the place of the grid
+		v.SetContent(BorderStyle.Render(tableRows[0] + "\n" + renderDetail(*e, m.gridWidth(), m.location())))
+		return v
+	}
+
+	// All-day banner rows
+	for i, row := range m.allDayRows() {
+		timeLabel := ""
+		if i == 0 {
+			timeLabel = "all-day"
+		}
 
-			var rowParts []string
-			rowParts = append(rowParts, TimeLabelStyle.Width(7).Render(timeLabel))
-
-			for d := range m.ColumnCount {
-				cellTime := m.StartDate.AddDate(0, 0, d).Add(time.Hour * time.Duration(hour)).Add(time.Minute * time.Duration(min))
-				cell := EmptyStyle.Width(m.ColWidth).Render("")
-
-				for _, e := range m.Events {
-					// Check if cellTime is within event duration
-					if cellTime.Equal(e.StartTime) || (cellTime.After(e.StartTime) && cellTime.Before(e.EndTime)) {
-						eventStart := e.StartTime
-						eventEnd := e.EndTime
-						totalSlots := int(eventEnd.Sub(eventStart).Minutes()) / 30
-						slotIndex := int(cellTime.Sub(eventStart).Minutes()) / 30
-						maxTitleLen := m.ColWidth - 2
-						title := e.Title
-
-						// Split title into chunks
-						var chunks []string
-						for i := 0; i < len(title); i += maxTitleLen {
-							end := i + maxTitleLen
-							if end > len(title) {
-								end = len(title)
-							}
-							chunks = append(chunks, title[i:end])
-						}
-
-						// Render chunk if within event duration and title length
-						if slotIndex < len(chunks) && slotIndex < totalSlots {
-							if now.After(e.StartTime) && now.Before(e.EndTime) {
-								cell = EventStyleActive.Background(GetColorValue(e.Color)).Width(m.ColWidth).Render(chunks[slotIndex])
-							} else {
-								cell = EventStyle.Background(GetColorValue(e.Color)).Width(m.ColWidth).Render(chunks[slotIndex])
-							}
-						} else if slotIndex < totalSlots {
-							cell = EventStyle.Background(GetColorValue(e.Color)).Width(m.ColWidth).Render("")
-						}
-						break
+		var rowParts []string
+		rowParts = append(rowParts, m.gutter(timeLabel, m.StartDate, false))
+		for d := 0; d < m.ColumnCount; {
+			span := 1
+			cell := emptyStyle(m.StartDate.AddDate(0, 0, d)).Width(m.ColWidth).Render("")
+			for _, e := range row {
+				first, last, _ := m.dayColumns(e)
+				if first == d {
+					span = last - first + 1
+					width := span*m.ColWidth + span - 1 // spanned columns plus their separators
+					title := e.DisplayTitle()
+					if e.StartTime.Before(m.StartDate) {
+						title = "cont. " + title
 					}
+					style := eventStyle(e)
+					if eventKey(e) == m.Cursor {
+						style = style.Reverse(true)
+					}
+					cell = style.Width(width).Render(truncate(title, width))
+					break
 				}
+			}
 
-				rowParts = append(rowParts, cell)
-				if d < m.ColumnCount-1 {
-					rowParts = append(rowParts, SeparatorStyle.Width(1).Render("|"))
-				}
+			rowParts = append(rowParts, cell)
+			d += span
+			if d < m.ColumnCount {
+				rowParts = append(rowParts, SeparatorStyle.Width(1).Render("|"))
 			}
-			tableRows = append(tableRows, strings.Join(rowParts, ""))
 		}
+		tableRows = append(tableRows, strings.Join(rowParts, ""))
 	}
 
-	// Footer
-	var footerText string
-	if m.ColumnCount == 1 {
-		footerText = "\n←/→: Prev/Next day   q: Quit\n"
-	} else {
-		footerText = "\n←/→: Prev/Next week   q: Quit\n"
+	// Time rows, only those in the viewport
+	now := m.now()
+	nowColumn := dayIndex(m.StartDate, now)
+	rows, scrolling := m.viewportRows()
+	if scrolling {
+		tableRows = append(tableRows, m.hiddenRow(rows, true))
+	}
+	lanes := make([][]laneEvent, m.ColumnCount)
+	for d := range lanes {
+		lanes[d] = m.dayLanes(m.StartDate.AddDate(0, 0, d))
 	}
+	for slot := m.Scroll; slot < m.Scroll+rows; slot++ {
+		rowTime := m.slotTime(m.referenceDay(), slot)
+		timeLabel := ""
+		if rowTime.Minute() == 0 {
+			timeLabel = rowTime.Format("15:04")
+		}
+
+		var rowParts []string
+		rowStart := m.slotTime(m.StartDate.AddDate(0, 0, max(nowColumn, 0)), slot)
+		activeRow := nowColumn >= 0 && nowColumn < m.ColumnCount &&
+			!now.Before(rowStart) && now.Before(rowStart.Add(m.slot()))
+		if activeRow {
+			rowParts = append(rowParts, m.nowGutter(now))
+		} else {
+			rowParts = append(rowParts, m.gutter(timeLabel, rowTime, rowTime.Minute() == 0))
+		}
 
-	v.SetContent(BorderStyle.Render(strings.Join(tableRows, "\n") + footerText))
+		for d := range m.ColumnCount {
+			dayStart := m.StartDate.AddDate(0, 0, d)
+			rowParts = append(rowParts, m.renderCell(lanes[d], gridCell{
+				dayStart:    dayStart,
+				start:       m.slotTime(dayStart, slot),
+				viewportTop: m.slotTime(dayStart, m.Scroll),
+				now:         now,
+				nowLine:     activeRow && d == nowColumn,
+			}))
+			if d < m.ColumnCount-1 {
+				rowParts = append(rowParts, SeparatorStyle.Width(1).Render("|"))
+			}
+		}
+		tableRows = append(tableRows, strings.Join(rowParts, ""))
+	}
+	if scrolling {
+		tableRows = append(tableRows, m.hiddenRow(rows, false))
+	}
+
+	v.SetContent(BorderStyle.Render(strings.Join(tableRows, "\n") + "\n" + m.footer()))
 	return v
 }
+
+// Ways an event can cover a grid cell
+const (
+	coverFull   = iota // drawn as a whole cell, with its title
+	coverTop           // ends in the upper half of the cell
+	coverBottom        // starts in the lower half of the cell
+)
+
+// coverage tells how the event segment from segStart to segEnd covers the
+// cell starting at cellStart. Events that start late or end early in a cell
+// only take the matching half, as long as they get a whole cell elsewhere
+// for the title, so short and off-grid events stay visible.
+func (m Model) coverage(segStart, segEnd, cellStart time.Time) int {
+	half := cellStart.Add(m.slot() / 2)
+	cellEnd := cellStart.Add(m.slot())
+	switch {
+	case !segStart.Before(half) && segEnd.After(cellEnd):
+		return coverBottom
+	case !segEnd.After(half) && segStart.Before(cellStart.Add(-m.slot()/2)):
+		// the cell above is drawn whole
+		return coverTop
+	default:
+		return coverFull
+	}
+}
+
+// partialCell draws a cell shared by an event ending in its upper half and
+// one starting in its lower half, either of which may be nil, with half
+// blocks in the event colors.
+func partialCell(endsAbove, startsBelow *CalendarEvent, width int) string {
+	style := EmptyStyle
+	block := "▄"
+	if startsBelow != nil {
+		style = style.Foreground(GetColorValue(startsBelow.Color))
+	}
+	if endsAbove != nil {
+		block = "▀"
+		style = style.Foreground(GetColorValue(endsAbove.Color))
+		if startsBelow != nil {
+			style = style.Background(GetColorValue(startsBelow.Color))
+		}
+	}
+	return style.Width(width).Render(strings.Repeat(block, width))
+}
+
+// hiddenRow renders the count of events above, or below, the viewport in
+// each column.
+func (m Model) hiddenRow(rows int, above bool) string {
+	rowParts := []string{m.gutter("", m.StartDate, false)}
+	for d := range m.ColumnCount {
+		countAbove, countBelow := m.hiddenEvents(m.StartDate.AddDate(0, 0, d), rows)
+		label := ""
+		if above && countAbove > 0 {
+			label = fmt.Sprintf("▲ %d", countAbove)
+		} else if !above && countBelow > 0 {
+			label = fmt.Sprintf("▼ %d", countBelow)
+		}
+		rowParts = append(rowParts, ScrollHintStyle.Width(m.ColWidth).Render(label))
+		if d < m.ColumnCount-1 {
+			rowParts = append(rowParts, SeparatorStyle.Width(1).Render("|"))
+		}
+	}
+	return strings.Join(rowParts, "")
+}
+
+// footer renders the key help below the grid, preceded by status lines.
+func (m Model) footer() string {
+	var footerText string
+	switch m.period() {
+	case 1:
+		footerText = "\n←/→: Prev/Next day   "
+	case 7:
+		footerText = "\n←/→: Prev/Next week   "
+	default:
+		footerText = fmt.Sprintf("\n←/→: Prev/Next %d days   ", m.period())
+	}
+	footerText += "↑/↓: Scroll   J/K: Scroll line   tab: Select   enter: Details   r: Refresh   j: Join   "
+	if m.isWeekView() {
+		footerText += "w: Weekends   "
+	}
+	if len(m.Zones) > 1 {
+		footerText += "z: Swap zone   "
+	}
+	if m.InMonth {
+		footerText += "m: Month   "
+	}
+	footerText += "q: Quit\n"
+	if m.Status != "" {
+		footerText = "\n" + m.Status + footerText
+	}
+	if lines := m.statusLines(m.location()); len(lines) > 0 {
+		footerText = "\n" + strings.Join(lines, "\n") + footerText
+	}
+
+	footerText = strings.TrimPrefix(footerText, "\n")
+	if m.Width > 0 {
+		// wrap the footer to the grid on narrow terminals
+		footerText = lipgloss.NewStyle().Width(m.gridWidth()).Render(footerText)
+	}
+	return footerText
+}
+
+// gridWidth returns the width of the grid rows.
+func (m Model) gridWidth() int {
+	return gutterWidth + zoneGutterWidth*max(len(m.Zones)-1, 0) + m.ColumnCount*m.ColWidth + m.ColumnCount - 1
+}
@@ -0,0 +1,563 @@
+package calendar
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+	"time"
+
+	tea "charm.land/bubbletea/v2"
+	"charm.land/lipgloss/v2"
+	"github.com/kahnwong/gcal-tui/internal/utils"
+)
+
+func TestAllDayRows(t *testing.T) {
+	monday := time.Date(2026, 2, 2, 0, 0, 0, 0, time.UTC)
+	day := func(d int) time.Time { return monday.AddDate(0, 0, d) }
+
+	m := Model{
+		StartDate:   monday,
+		ColumnCount: 7,
+		ColWidth:    20,
+		Events: []CalendarEvent{
+			{Title: "Conference", StartTime: day(1), EndTime: day(4), AllDay: true},
+			{Title: "Holiday", StartTime: day(4), EndTime: day(5), AllDay: true},
+			{Title: "Overlapping", StartTime: day(2), EndTime: day(3), AllDay: true},
+			{Title: "Last week", StartTime: day(-3), EndTime: day(-2), AllDay: true},
+			{Title: "Timed", StartTime: day(1).Add(10 * time.Hour), EndTime: day(1).Add(11 * time.Hour)},
+		},
+	}
+
+	rows := m.allDayRows()
+	if len(rows) != 2 {
+		t.Fatalf("Expected 2 banner rows, got %d", len(rows))
+	}
+	if len(rows[0]) != 2 || rows[0][0].Title != "Conference" || rows[0][1].Title != "Holiday" {
+		t.Errorf("Expected Conference and Holiday to share the first row, got %+v", rows[0])
+	}
+	if len(rows[1]) != 1 || rows[1][0].Title != "Overlapping" {
+		t.Errorf("Expected Overlapping on its own row, got %+v", rows[1])
+	}
+}
+
+func TestDayColumns(t *testing.T) {
+	monday := time.Date(2026, 2, 2, 0, 0, 0, 0, time.UTC)
+	m := Model{StartDate: monday, ColumnCount: 7}
+
+	tests := []struct {
+		name      string
+		start     time.Time
+		end       time.Time
+		wantFirst int
+		wantLast  int
+		wantOk    bool
+	}{
+		{"single day", monday.AddDate(0, 0, 2), monday.AddDate(0, 0, 3), 2, 2, true},
+		{"clipped at start", monday.AddDate(0, 0, -2), monday.AddDate(0, 0, 2), 0, 1, true},
+		{"clipped at end", monday.AddDate(0, 0, 5), monday.AddDate(0, 0, 10), 5, 6, true},
+		{"before range", monday.AddDate(0, 0, -2), monday, 0, 0, false},
+		{"after range", monday.AddDate(0, 0, 7), monday.AddDate(0, 0, 8), 0, 0, false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			first, last, ok := m.dayColumns(CalendarEvent{StartTime: tt.start, EndTime: tt.end, AllDay: true})
+			if first != tt.wantFirst || last != tt.wantLast || ok != tt.wantOk {
+				t.Errorf("dayColumns() = (%d, %d, %v), want (%d, %d, %v)", first, last, ok, tt.wantFirst, tt.wantLast, tt.wantOk)
+			}
+		})
+	}
+}
+
+func TestTruncate(t *testing.T) {
+	tests := []struct {
+		input    string
+		width    int
+		expected string
+	}{
+		{"short", 10, "short"},
+		{"exactly ten", 11, "exactly ten"},
+		{"a long event title", 8, "a long …"},
+		{"café au lait", 5, "café…"},
+		{"x", 0, ""},
+	}
+	for _, tt := range tests {
+		if result := truncate(tt.input, tt.width); result != tt.expected {
+			t.Errorf("truncate(%q, %d) = %q, want %q", tt.input, tt.width, result, tt.expected)
+		}
+	}
+}
+
+func TestClipToDay(t *testing.T) {
+	day := time.Date(2026, 2, 2, 0, 0, 0, 0, time.UTC)
+	onCall := CalendarEvent{
+		Title:     "On-call",
+		StartTime: day.Add(22 * time.Hour),
+		EndTime:   day.Add(26 * time.Hour),
+	}
+
+	tests := []struct {
+		name      string
+		dayStart  time.Time
+		wantStart time.Time
+		wantEnd   time.Time
+		wantOk    bool
+		wantTitle string
+	}{
+		{"first day ends at midnight", day, day.Add(22 * time.Hour), day.Add(24 * time.Hour), true, "On-call"},
+		{"next day is a continuation", day.AddDate(0, 0, 1), day.Add(24 * time.Hour), day.Add(26 * time.Hour), true, "cont. On-call"},
+		{"previous day is untouched", day.AddDate(0, 0, -1), time.Time{}, time.Time{}, false, ""},
+		{"day after is untouched", day.AddDate(0, 0, 2), time.Time{}, time.Time{}, false, ""},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			start, end, ok := clipToDay(onCall, tt.dayStart)
+			if ok != tt.wantOk || !start.Equal(tt.wantStart) || !end.Equal(tt.wantEnd) {
+				t.Errorf("clipToDay() = (%v, %v, %v), want (%v, %v, %v)", start, end, ok, tt.wantStart, tt.wantEnd, tt.wantOk)
+			}
+			if ok {
+				if title := segmentTitle(onCall, start); title != tt.wantTitle {
+					t.Errorf("segmentTitle() = %q, want %q", title, tt.wantTitle)
+				}
+			}
+		})
+	}
+}
+
+func TestInBanner(t *testing.T) {
+	start := time.Date(2026, 2, 2, 9, 0, 0, 0, time.UTC)
+	tests := []struct {
+		name     string
+		event    CalendarEvent
+		expected bool
+	}{
+		{"all-day event", CalendarEvent{StartTime: start, EndTime: start.Add(24 * time.Hour), AllDay: true}, true},
+		{"overnight event stays in the grid", CalendarEvent{StartTime: start, EndTime: start.Add(17 * time.Hour)}, false},
+		{"24 hour shift stays in the grid", CalendarEvent{StartTime: start, EndTime: start.Add(24 * time.Hour)}, false},
+		{"just over a day", CalendarEvent{StartTime: start, EndTime: start.Add(24*time.Hour + time.Minute)}, true},
+		{"three day conference", CalendarEvent{StartTime: start, EndTime: start.Add(56 * time.Hour)}, true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if result := inBanner(tt.event); result != tt.expected {
+				t.Errorf("inBanner() = %v, want %v", result, tt.expected)
+			}
+		})
+	}
+}
+
+func TestChunkTitle(t *testing.T) {
+	chunks := chunkTitle("cont. Weekly sync", 6)
+	expected := []string{"cont. ", "Weekly", " sync"}
+	if len(chunks) != len(expected) {
+		t.Fatalf("Expected %d chunks, got %d: %q", len(expected), len(chunks), chunks)
+	}
+	for i := range expected {
+		if chunks[i] != expected[i] {
+			t.Errorf("chunk %d = %q, want %q", i, chunks[i], expected[i])
+		}
+	}
+}
+
+func TestDayIndexAcrossDST(t *testing.T) {
+	berlin, err := time.LoadLocation("Europe/Berlin")
+	if err != nil {
+		t.Fatalf("Failed to load location: %v", err)
+	}
+
+	// the week of 2026-03-23 has a 23 hour Sunday
+	monday := time.Date(2026, 3, 23, 0, 0, 0, 0, berlin)
+	nextMonday := time.Date(2026, 3, 30, 0, 30, 0, 0, berlin)
+	if index := dayIndex(monday, nextMonday); index != 7 {
+		t.Errorf("dayIndex() = %d, want 7", index)
+	}
+	if index := dayIndex(monday, nextMonday.UTC()); index != 7 {
+		t.Errorf("dayIndex() with a UTC time = %d, want 7", index)
+	}
+}
+
+func TestFormatZoneTime(t *testing.T) {
+	bangkok, _ := time.LoadLocation("Asia/Bangkok")
+	berlin, _ := time.LoadLocation("Europe/Berlin")
+	sanFrancisco, _ := time.LoadLocation("America/Los_Angeles")
+
+	tests := []struct {
+		name     string
+		t        time.Time
+		zone     *time.Location
+		expected string
+	}{
+		{"winter offset", time.Date(2026, 3, 27, 15, 0, 0, 0, bangkok), berlin, "09:00"},
+		{"summer offset after DST", time.Date(2026, 3, 30, 15, 0, 0, 0, bangkok), berlin, "10:00"},
+		{"previous day", time.Date(2026, 3, 30, 9, 0, 0, 0, bangkok), sanFrancisco, "19:00-1"},
+		{"next day", time.Date(2026, 3, 30, 20, 0, 0, 0, sanFrancisco), bangkok, "10:00+1"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if result := formatZoneTime(tt.t, tt.zone); result != tt.expected {
+				t.Errorf("formatZoneTime() = %q, want %q", result, tt.expected)
+			}
+		})
+	}
+}
+
+func TestSwapZone(t *testing.T) {
+	bangkok, _ := time.LoadLocation("Asia/Bangkok")
+	berlin, _ := time.LoadLocation("Europe/Berlin")
+	previous := utils.Location
+	t.Cleanup(func() { utils.Location = previous })
+	utils.Location = bangkok
+
+	start := time.Date(2026, 2, 2, 0, 0, 0, 0, bangkok)
+	eventStart := time.Date(2026, 2, 2, 16, 0, 0, 0, bangkok)
+	events := []CalendarEvent{
+		{Title: "Standup", StartTime: eventStart, EndTime: eventStart.Add(15 * time.Minute)},
+		{Title: "Holiday", StartTime: start.AddDate(0, 0, 1), EndTime: start.AddDate(0, 0, 2), AllDay: true},
+	}
+	m := Model{
+		StartDate:   start,
+		ColumnCount: 7,
+		Zones:       []*time.Location{bangkok, berlin},
+		Events:      events,
+	}
+
+	m = m.swapZone()
+	if m.Zones[0] != berlin || m.Zones[1] != bangkok || m.location() != berlin {
+		t.Fatalf("Expected Berlin to become primary, got %v", m.Zones)
+	}
+	if utils.Location != bangkok {
+		t.Errorf("Expected utils.Location to be left alone, got %v", utils.Location)
+	}
+	if !m.StartDate.Equal(time.Date(2026, 2, 2, 0, 0, 0, 0, berlin)) {
+		t.Errorf("Expected the same date at midnight in Berlin, got %v", m.StartDate)
+	}
+	if m.Events[0].StartTime.Hour() != 10 || !m.Events[0].StartTime.Equal(eventStart) {
+		t.Errorf("Expected the event at 10:00 Berlin time, got %v", m.Events[0].StartTime)
+	}
+	if !m.Events[1].StartTime.Equal(time.Date(2026, 2, 3, 0, 0, 0, 0, berlin)) {
+		t.Errorf("Expected the all-day event to stay on 3 February, got %v", m.Events[1].StartTime)
+	}
+	if events[0].StartTime.Location() != bangkok {
+		t.Error("Expected the original events, shared with the cache, to be left alone")
+	}
+	if got := m.allDayRows(); len(got) != 1 || dayIndex(m.StartDate, m.Events[1].StartTime) != 1 {
+		t.Errorf("Expected the holiday in Tuesday's column, got %v", got)
+	}
+
+	m = m.swapZone()
+	if m.location() != bangkok {
+		t.Errorf("Expected swapping twice to restore Bangkok, got %v", m.location())
+	}
+}
+
+func TestEventTypeLayout(t *testing.T) {
+	monday := time.Date(2026, 2, 2, 0, 0, 0, 0, time.UTC)
+	ooo := CalendarEvent{Title: "Out of office", EventType: EventTypeOutOfOffice, StartTime: monday.AddDate(0, 0, 1), EndTime: monday.AddDate(0, 0, 3)}
+	home := CalendarEvent{Title: "Home", EventType: EventTypeWorkingLocation, WorkingLocation: "Home", StartTime: monday, EndTime: monday.AddDate(0, 0, 1), AllDay: true}
+	m := Model{StartDate: monday, ColumnCount: 7, Events: []CalendarEvent{ooo, home}}
+
+	if inBanner(ooo) || inBanner(home) {
+		t.Error("Expected out of office and working location to stay out of the all-day strip")
+	}
+	if rows := m.allDayRows(); len(rows) != 0 {
+		t.Errorf("Expected no banner rows, got %d", len(rows))
+	}
+
+	if location := m.workingLocation(monday); location != "Home" {
+		t.Errorf("workingLocation(monday) = %q, want %q", location, "Home")
+	}
+	if location := m.workingLocation(monday.AddDate(0, 0, 1)); location != "" {
+		t.Errorf("workingLocation(tuesday) = %q, want none", location)
+	}
+
+	wednesday := monday.AddDate(0, 0, 2)
+	e, segStart, ok := m.outOfOfficeAt(wednesday.Add(10*time.Hour), wednesday)
+	if !ok || e.Title != "Out of office" || !segStart.Equal(wednesday) {
+		t.Errorf("Expected out of office shading on Wednesday, got (%v, %v, %v)", e.Title, segStart, ok)
+	}
+	if _, _, ok := m.outOfOfficeAt(monday.Add(10*time.Hour), monday); ok {
+		t.Error("Expected no out of office shading on Monday")
+	}
+}
+
+func TestHatch(t *testing.T) {
+	if result := hatch("OOO", 6); result != "OOO╱╱╱" {
+		t.Errorf("hatch() = %q, want %q", result, "OOO╱╱╱")
+	}
+	if result := hatch("too long", 3); result != "too long" {
+		t.Errorf("hatch() = %q, want the label unchanged", result)
+	}
+}
+
+func TestNowLine(t *testing.T) {
+	original := utils.Location
+	utils.Location = time.UTC
+	defer func() { utils.Location = original }()
+
+	monday := time.Date(2026, 2, 2, 0, 0, 0, 0, time.UTC)
+	m := Model{StartDate: monday, ColumnCount: 7, ColWidth: 10, Zones: []*time.Location{time.UTC}, Now: monday.Add(10*time.Hour + 47*time.Minute)}
+
+	if content := m.View().Content; !strings.Contains(content, "▶10:47") {
+		t.Errorf("Expected the now-line at 10:47, got:\n%s", content)
+	}
+
+	updated, cmd := m.Update(nowMsg(monday.Add(11*time.Hour + 3*time.Minute)))
+	if cmd == nil {
+		t.Error("Expected the ticker to be rescheduled")
+	}
+	if content := updated.(Model).View().Content; !strings.Contains(content, "▶11:03") || strings.Contains(content, "▶10:47") {
+		t.Errorf("Expected the now-line to move to 11:03, got:\n%s", content)
+	}
+
+	lastWeek := m
+	lastWeek.StartDate = monday.AddDate(0, 0, -7)
+	if content := lastWeek.View().Content; strings.Contains(content, "▶") {
+		t.Error("Expected no now-line when today is not shown")
+	}
+}
+
+func TestLoadDropsStaleResponses(t *testing.T) {
+	original := Fetcher
+	defer func() { Fetcher = original }()
+	Fetcher = func(weekStart, _ time.Time) ([]CalendarEvent, error) {
+		return []CalendarEvent{{Title: weekStart.Format("Jan 2")}}, nil
+	}
+
+	monday := time.Date(2026, 2, 2, 0, 0, 0, 0, time.UTC)
+	m := Model{StartDate: monday, ColumnCount: 7, ColWidth: 20, Events: []CalendarEvent{{Title: "Old"}}}
+
+	m, _ = m.load()
+	first := fetchCmd(m.StartDate, m.ColumnCount, m.Request)().(eventsMsg)
+	if !m.Loading {
+		t.Fatal("Expected the model to be loading")
+	}
+
+	updated, _ := m.Update(tea.KeyPressMsg{Code: tea.KeyRight})
+	m = updated.(Model)
+	if len(m.Events) != 1 || m.Events[0].Title != "Old" {
+		t.Errorf("Expected the previous events to stay while loading, got %+v", m.Events)
+	}
+
+	updated, _ = m.Update(first)
+	m = updated.(Model)
+	if !m.Loading || m.Events[0].Title != "Old" {
+		t.Errorf("Expected the response for the previous week to be dropped, got %+v", m.Events)
+	}
+
+	updated, _ = m.Update(fetchCmd(m.StartDate, m.ColumnCount, m.Request)())
+	m = updated.(Model)
+	if m.Loading || len(m.Events) != 1 || m.Events[0].Title != "Feb 9" {
+		t.Errorf("Expected the events for Feb 9, got %+v (loading %v)", m.Events, m.Loading)
+	}
+}
+
+func TestPrefetchServesNavigationFromCache(t *testing.T) {
+	original := Fetcher
+	defer func() { Fetcher = original }()
+	Fetcher = func(weekStart, _ time.Time) ([]CalendarEvent, error) {
+		return []CalendarEvent{{Title: weekStart.Format("Jan 2")}}, nil
+	}
+
+	monday := time.Date(2026, 2, 2, 0, 0, 0, 0, time.UTC)
+	m := Model{StartDate: monday, ColumnCount: 7, ColWidth: 20, loader: newLoader()}
+
+	updated, cmd := m.Update(fetchCmd(monday, m.ColumnCount, 1)())
+	m = updated.(Model)
+	if cmd == nil {
+		t.Fatal("Expected the adjacent weeks to be prefetched")
+	}
+	for _, start := range []time.Time{monday.AddDate(0, 0, -7), monday.AddDate(0, 0, 7)} {
+		updated, cmd = m.Update(fetchCmd(start, m.ColumnCount, 0)())
+		m = updated.(Model)
+		if cmd != nil {
+			t.Error("Expected a prefetch not to trigger further prefetches")
+		}
+		if m.Events[0].Title != "Feb 2" {
+			t.Errorf("Expected a prefetch not to change the visible events, got %+v", m.Events)
+		}
+	}
+
+	updated, cmd = m.Update(tea.KeyPressMsg{Code: tea.KeyRight})
+	m = updated.(Model)
+	if len(m.Events) != 1 || m.Events[0].Title != "Feb 9" {
+		t.Errorf("Expected the cached events for Feb 9 right away, got %+v", m.Events)
+	}
+	if !m.Loading || cmd == nil {
+		t.Error("Expected the cached week to be fetched again")
+	}
+
+	m.cache.prune(m.StartDate.AddDate(0, 0, 21), m.period())
+	if _, ok := m.cache.get(monday.AddDate(0, 0, -7), 7); ok {
+		t.Error("Expected weeks out of reach to be pruned")
+	}
+}
+
+func TestRefresh(t *testing.T) {
+	original := Fetcher
+	defer func() { Fetcher = original }()
+	fetched := 0
+	Fetcher = func(weekStart, _ time.Time) ([]CalendarEvent, error) {
+		fetched++
+		return []CalendarEvent{{Title: fmt.Sprintf("Fetch %d", fetched)}}, nil
+	}
+
+	monday := time.Date(2026, 2, 2, 0, 0, 0, 0, time.UTC)
+	m := Model{StartDate: monday, ColumnCount: 7, ColWidth: 20, Zones: []*time.Location{time.UTC}}
+
+	for _, msg := range []tea.Msg{refreshMsg{}, tea.KeyPressMsg{Code: 'r', Text: "r"}} {
+		updated, cmd := m.Update(msg)
+		m = updated.(Model)
+		if !m.Loading || cmd == nil {
+			t.Fatalf("Expected %T to start a fetch", msg)
+		}
+		updated, _ = m.Update(fetchCmd(m.StartDate, m.ColumnCount, m.Request)())
+		m = updated.(Model)
+	}
+
+	if m.Events[0].Title != "Fetch 2" {
+		t.Errorf("Expected the refreshed events, got %+v", m.Events)
+	}
+	if m.Updated.IsZero() || !strings.Contains(m.View().Content, "Last updated: ") {
+		t.Error("Expected the footer to say when the events were last updated")
+	}
+}
+
+func TestLayout(t *testing.T) {
+	monday := time.Date(2026, 2, 2, 0, 0, 0, 0, time.UTC)
+	tests := []struct {
+		name      string
+		columns   int
+		zones     []*time.Location
+		width     int
+		wantLabel string
+	}{
+		{"wide week", 7, []*time.Location{time.UTC}, 200, "Mon 02/02"},
+		{"120 column week", 7, []*time.Location{time.UTC}, 120, "Mon 02/02"},
+		{"narrow week", 7, []*time.Location{time.UTC}, 70, "M 02/02"},
+		{"narrow week with zones", 7, []*time.Location{time.UTC, time.UTC}, 60, "M02"},
+		{"today", 1, []*time.Location{time.UTC}, 80, "Monday 02/02"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			m := Model{StartDate: monday, ColumnCount: tt.columns, ColWidth: 20, Zones: tt.zones, Now: monday}
+			updated, _ := m.Update(tea.WindowSizeMsg{Width: tt.width, Height: 40})
+			m = updated.(Model)
+
+			if label := m.dayLabel(monday); label != tt.wantLabel {
+				t.Errorf("dayLabel() = %q, want %q", label, tt.wantLabel)
+			}
+			for _, line := range strings.Split(m.View().Content, "\n") {
+				if width := lipgloss.Width(line); width > tt.width {
+					t.Fatalf("Expected lines to fit in %d columns, got %d: %q", tt.width, width, line)
+				}
+			}
+		})
+	}
+}
+
+func TestViewLength(t *testing.T) {
+	wednesday := time.Date(2026, 2, 4, 0, 0, 0, 0, time.UTC)
+	monday := time.Date(2026, 2, 2, 0, 0, 0, 0, time.UTC)
+	tests := []struct {
+		name       string
+		model      Model
+		wantNext   time.Time
+		wantLabels []string
+		wantHelp   string
+	}{
+		{"one day", Model{StartDate: wednesday, ColumnCount: 1}, wednesday.AddDate(0, 0, 1), []string{"Wednesday 02/04"}, "Prev/Next day"},
+		{"three days", Model{StartDate: wednesday, ColumnCount: 3}, wednesday.AddDate(0, 0, 3), []string{"Wed 02/04", "Thu 02/05", "Fri 02/06"}, "Prev/Next 3 days"},
+		{"work week", Model{StartDate: monday, ColumnCount: 5, Period: 7}, monday.AddDate(0, 0, 7), []string{"Mon 02/02", "Fri 02/06"}, "Prev/Next week"},
+		{"week", Model{StartDate: monday, ColumnCount: 7}, monday.AddDate(0, 0, 7), []string{"Mon 02/02", "Sun 02/08"}, "Prev/Next week"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			m := tt.model
+			m.Zones = []*time.Location{time.UTC}
+			m = m.layout(200, 40)
+
+			content := m.View().Content
+			for _, label := range tt.wantLabels {
+				if !strings.Contains(content, label) {
+					t.Errorf("Expected the header %q", label)
+				}
+			}
+			if !strings.Contains(content, tt.wantHelp) {
+				t.Errorf("Expected the footer to say %q", tt.wantHelp)
+			}
+
+			updated, _ := m.Update(tea.KeyPressMsg{Code: tea.KeyRight})
+			if next := updated.(Model); !next.StartDate.Equal(tt.wantNext) {
+				t.Errorf("Expected right to move to %v, got %v", tt.wantNext, next.StartDate)
+			}
+		})
+	}
+}
+
+func TestCoverage(t *testing.T) {
+	day := time.Date(2026, 2, 2, 0, 0, 0, 0, time.UTC)
+	at := func(hour, min int) time.Time {
+		return day.Add(time.Duration(hour)*time.Hour + time.Duration(min)*time.Minute)
+	}
+	cell := at(10, 0)
+
+	tests := []struct {
+		name       string
+		slot       time.Duration
+		start, end time.Time
+		want       int
+	}{
+		{"whole cell", 30 * time.Minute, at(10, 0), at(10, 30), coverFull},
+		{"starts late", 30 * time.Minute, at(10, 15), at(10, 45), coverBottom},
+		{"starts early in the cell", 30 * time.Minute, at(10, 10), at(10, 45), coverFull},
+		{"ends early", 30 * time.Minute, at(9, 30), at(10, 15), coverTop},
+		{"ends late in the cell", 30 * time.Minute, at(9, 30), at(10, 20), coverFull},
+		{"short event inside", 30 * time.Minute, at(10, 15), at(10, 25), coverFull},
+		{"short event across cells", 30 * time.Minute, at(9, 50), at(10, 10), coverFull},
+		{"hour slots", time.Hour, at(10, 30), at(11, 30), coverBottom},
+		{"quarter hour slots", 15 * time.Minute, at(10, 0), at(10, 15), coverFull},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			m := Model{Slot: tt.slot}
+			if result := m.coverage(tt.start, tt.end, cell); result != tt.want {
+				t.Errorf("coverage() = %d, want %d", result, tt.want)
+			}
+		})
+	}
+}
+
+func TestOffGridEvents(t *testing.T) {
+	original := utils.Location
+	utils.Location = time.UTC
+	defer func() { utils.Location = original }()
+
+	day := time.Date(2026, 2, 2, 0, 0, 0, 0, time.UTC)
+	at := func(hour, min int) time.Time {
+		return day.Add(time.Duration(hour)*time.Hour + time.Duration(min)*time.Minute)
+	}
+	m := Model{
+		StartDate:   day,
+		ColumnCount: 1,
+		ColWidth:    20,
+		Zones:       []*time.Location{time.UTC},
+		Now:         day.AddDate(0, 0, 1),
+		Scroll:      18, // 09:00
+		Events: []CalendarEvent{
+			{Title: "Review", StartTime: at(10, 15), EndTime: at(10, 45)},
+			{Title: "Coffee", StartTime: at(11, 0), EndTime: at(11, 10)},
+		},
+	}
+
+	content := m.View().Content
+	for _, want := range []string{"Review", "Coffee", "▄"} {
+		if !strings.Contains(content, want) {
+			t.Errorf("Expected %q in the grid, got:\n%s", want, content)
+		}
+	}
+	lines := strings.Split(content, "\n")
+	for i, line := range lines {
+		if strings.Contains(line, "Review") && (i == 0 || !strings.Contains(lines[i-1], "▄")) {
+			t.Errorf("Expected Review to start half way through the row above its title")
+		}
+	}
+}
@@ -0,0 +1,78 @@
+package calendar
+
+import (
+	"time"
+
+	"charm.land/lipgloss/v2"
+	"github.com/kahnwong/gcal-tui/configs"
+	"github.com/kahnwong/gcal-tui/internal/utils"
+)
+
+// WeekStart is the day week views and the month grid start on.
+var WeekStart = configs.DefaultWeekStart
+
+// HideWeekends starts week views with Monday to Friday only.
+var HideWeekends = false
+
+// Styles for weekend columns
+var (
+	WeekendStyle       = lipgloss.NewStyle().Background(lipgloss.Color("#181818")).Foreground(lipgloss.Color("#888"))
+	WeekendHeaderStyle = HeaderStyle.Background(lipgloss.Color("#AAA"))
+)
+
+// startOfWeek returns the start of the first day of the week holding t, in
+// t's time zone.
+func startOfWeek(t time.Time) time.Time {
+	offset := (int(t.Weekday()) - int(WeekStart) + 7) % 7
+	return utils.StartOfDayIn(t, t.Location()).AddDate(0, 0, -offset)
+}
+
+// isWeekend reports whether t falls on a Saturday or Sunday.
+func isWeekend(t time.Time) bool {
+	return t.Weekday() == time.Saturday || t.Weekday() == time.Sunday
+}
+
+// emptyStyle returns the style for an empty cell on day, shaded on weekends.
+func emptyStyle(day time.Time) lipgloss.Style {
+	if isWeekend(day) {
+		return WeekendStyle
+	}
+	return EmptyStyle
+}
+
+// headerStyle returns the style for the header of day's column.
+func headerStyle(day time.Time) lipgloss.Style {
+	if isWeekend(day) {
+		return WeekendHeaderStyle
+	}
+	return HeaderStyle
+}
+
+// isWeekView reports whether the model shows a whole week, with or without
+// its weekend, so weekends can be toggled.
+func (m Model) isWeekView() bool {
+	switch m.ColumnCount {
+	case 7:
+		return m.StartDate.Equal(startOfWeek(m.StartDate))
+	case 5:
+		return m.period() == 7 && m.StartDate.Weekday() == time.Monday
+	default:
+		return false
+	}
+}
+
+// withWeekends shows the week holding StartDate with all seven days, or with
+// Monday to Friday only. Weeks starting on Saturday or Sunday have their
+// weekend at one end, so the weekdays left are still consecutive.
+func (m Model) withWeekends(show bool) Model {
+	weekStart := startOfWeek(m.StartDate)
+	m.StartDate, m.ColumnCount, m.Period = weekStart, 7, 7
+	if !show {
+		m.StartDate = weekStart.AddDate(0, 0, (int(time.Monday)-int(WeekStart)+7)%7)
+		m.ColumnCount = 5
+	}
+	if m.Width > 0 {
+		return m.layout(m.Width, m.Height)
+	}
+	return m.autoScroll()
+}
@@ -0,0 +1,134 @@
+package calendar
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+	"time"
+
+	tea "charm.land/bubbletea/v2"
+)
+
+func TestStartOfWeek(t *testing.T) {
+	original := WeekStart
+	defer func() { WeekStart = original }()
+
+	wednesday := time.Date(2026, 2, 4, 15, 0, 0, 0, time.UTC)
+	sunday := time.Date(2026, 2, 8, 0, 0, 0, 0, time.UTC)
+	tests := []struct {
+		name      string
+		weekStart time.Weekday
+		day       time.Time
+		want      time.Time
+	}{
+		{"monday start", time.Monday, wednesday, time.Date(2026, 2, 2, 0, 0, 0, 0, time.UTC)},
+		{"monday start on a Sunday", time.Monday, sunday, time.Date(2026, 2, 2, 0, 0, 0, 0, time.UTC)},
+		{"sunday start", time.Sunday, wednesday, time.Date(2026, 2, 1, 0, 0, 0, 0, time.UTC)},
+		{"sunday start on a Sunday", time.Sunday, sunday, sunday},
+		{"saturday start", time.Saturday, wednesday, time.Date(2026, 1, 31, 0, 0, 0, 0, time.UTC)},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			WeekStart = tt.weekStart
+			if got := startOfWeek(tt.day); !got.Equal(tt.want) {
+				t.Errorf("startOfWeek(%v) = %v, want %v", tt.day, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestToggleWeekends(t *testing.T) {
+	original := WeekStart
+	defer func() { WeekStart = original }()
+
+	for _, weekStart := range []time.Weekday{time.Sunday, time.Monday, time.Saturday} {
+		t.Run(weekStart.String(), func(t *testing.T) {
+			WeekStart = weekStart
+			start := startOfWeek(time.Date(2026, 2, 4, 0, 0, 0, 0, time.UTC))
+			m := Model{StartDate: start, ColumnCount: 7, Zones: []*time.Location{time.UTC}}
+			m = m.layout(200, 40)
+
+			content := m.View().Content
+			if !strings.Contains(content, start.Format("Mon 01/02")) || !strings.Contains(content, "w: Weekends") {
+				t.Fatalf("Expected the week to start with %s and offer hiding weekends", start.Format("Mon 01/02"))
+			}
+
+			updated, _ := m.Update(tea.KeyPressMsg{Code: 'w', Text: "w"})
+			m = updated.(Model)
+			if m.ColumnCount != 5 || m.StartDate.Weekday() != time.Monday || m.period() != 7 {
+				t.Fatalf("Expected Monday to Friday, got %d columns from %v", m.ColumnCount, m.StartDate)
+			}
+			if content := m.View().Content; strings.Contains(content, "Sat") || strings.Contains(content, "Sun") {
+				t.Error("Expected no weekend columns")
+			}
+
+			updated, _ = m.Update(tea.KeyPressMsg{Code: tea.KeyRight})
+			m = updated.(Model)
+			updated, _ = m.Update(tea.KeyPressMsg{Code: 'w', Text: "w"})
+			m = updated.(Model)
+			if want := start.AddDate(0, 0, 7); m.ColumnCount != 7 || !m.StartDate.Equal(want) {
+				t.Errorf("Expected the whole next week from %v, got %d columns from %v", want, m.ColumnCount, m.StartDate)
+			}
+		})
+	}
+}
+
+func TestToggleWeekendsCachesEachLength(t *testing.T) {
+	original, originalStart := Fetcher, WeekStart
+	defer func() { Fetcher, WeekStart = original, originalStart }()
+	WeekStart = time.Monday
+	Fetcher = func(start, end time.Time) ([]CalendarEvent, error) {
+		return []CalendarEvent{{Title: fmt.Sprintf("%d days", dayIndex(start, end))}}, nil
+	}
+
+	monday := time.Date(2026, 2, 2, 0, 0, 0, 0, time.UTC)
+	m := Model{StartDate: monday, ColumnCount: 7, Zones: []*time.Location{time.UTC}, loader: loader{Request: 1}}
+	updated, _ := m.Update(fetchCmd(monday, 7, 1)())
+	m = updated.(Model)
+	for _, start := range []time.Time{monday.AddDate(0, 0, -7), monday.AddDate(0, 0, 7)} {
+		updated, _ = m.Update(fetchCmd(start, 7, 0)())
+		m = updated.(Model)
+	}
+
+	updated, _ = m.Update(tea.KeyPressMsg{Code: 'w', Text: "w"})
+	m = updated.(Model)
+	updated, cmd := m.Update(fetchCmd(monday, 5, m.Request)())
+	m = updated.(Model)
+	if m.Events[0].Title != "5 days" {
+		t.Errorf("Expected the work week's events, got %+v", m.Events)
+	}
+	prefetched := 0
+	for _, msg := range cmd().(tea.BatchMsg) {
+		if got := msg().(eventsMsg); got.days == 5 {
+			prefetched++
+		}
+	}
+	if prefetched != 2 {
+		t.Errorf("Expected both neighbouring work weeks to be prefetched, got %d", prefetched)
+	}
+
+	updated, _ = m.Update(tea.KeyPressMsg{Code: 'w', Text: "w"})
+	if got := updated.(Model); got.Events[0].Title != "7 days" {
+		t.Errorf("Expected the cached week right away, got %+v", got.Events)
+	}
+}
+
+func TestToggleWeekendsOnlyInWeekViews(t *testing.T) {
+	wednesday := time.Date(2026, 2, 4, 0, 0, 0, 0, time.UTC)
+	m := Model{StartDate: wednesday, ColumnCount: 7, Zones: []*time.Location{time.UTC}}
+
+	updated, _ := m.Update(tea.KeyPressMsg{Code: 'w', Text: "w"})
+	if got := updated.(Model); got.ColumnCount != 7 || !got.StartDate.Equal(wednesday) {
+		t.Errorf("Expected a rolling 7 day view to stay as it is, got %d columns from %v", got.ColumnCount, got.StartDate)
+	}
+}
+
+func TestWeekendShading(t *testing.T) {
+	saturday := time.Date(2026, 2, 7, 0, 0, 0, 0, time.UTC)
+	if emptyStyle(saturday).GetBackground() != WeekendStyle.GetBackground() {
+		t.Error("Expected Saturday to be shaded")
+	}
+	if emptyStyle(saturday.AddDate(0, 0, 2)).GetBackground() != EmptyStyle.GetBackground() {
+		t.Error("Expected Monday not to be shaded")
+	}
+}
@@ -30,7 +30,9 @@ func ListCalendars(srv *calendar.Service) error {
 	return nil
 }
 
-func GetEvents(weekStart time.Time, calendarId string, client *http.Client) (*calendar.Events, error) {
+// GetEvents returns the events of a calendar overlapping [start, end), following
+// every page of results.
+func GetEvents(start, end time.Time, calendarId string, showDeleted bool, client *http.Client) (*calendar.Events, error) {
 	srv, err := calendar.NewService(ctx, option.WithHTTPClient(client))
 	if err != nil {
 		return nil, fmt.Errorf("unable to retrieve Calendar client: %w", err)
@@ -40,13 +42,51 @@ func GetEvents(weekStart time.Time, calendarId string, client *http.Client) (*ca
 	// ListCalendars(srv)
 
 	// show events
-	events, err := srv.Events.List(calendarId).ShowDeleted(false).
+	var events *calendar.Events
+	err = srv.Events.List(calendarId).ShowDeleted(showDeleted).
 		SingleEvents(true).
-		TimeMin(weekStart.Format(time.RFC3339)).
-		MaxResults(15).OrderBy("startTime").Do()
+		TimeMin(start.Format(time.RFC3339)).
+		TimeMax(end.Format(time.RFC3339)).
+		MaxResults(250).OrderBy("startTime").
+		Pages(ctx, func(page *calendar.Events) error {
+			if events == nil {
+				events = page
+			} else {
+				events.Items = append(events.Items, page.Items...)
+			}
+			return nil
+		})
 	if err != nil {
-		return nil, fmt.Errorf("unable to retrieve next ten of the user's events: %w", err)
+		return nil, fmt.Errorf("unable to retrieve the user's events: %w", err)
 	}
 
 	return events, nil
 }
+
+func GetColors(client *http.Client) (*calendar.Colors, error) {
+	srv, err := calendar.NewService(ctx, option.WithHTTPClient(client))
+	if err != nil {
+		return nil, fmt.Errorf("unable to retrieve Calendar client: %w", err)
+	}
+
+	colors, err := srv.Colors.Get().Do()
+	if err != nil {
+		return nil, fmt.Errorf("unable to retrieve color palette: %w", err)
+	}
+
+	return colors, nil
+}
+
+func GetCalendarListEntry(calendarId string, client *http.Client) (*calendar.CalendarListEntry, error) {
+	srv, err := calendar.NewService(ctx, option.WithHTTPClient(client))
+	if err != nil {
+		return nil, fmt.Errorf("unable to retrieve Calendar client: %w", err)
+	}
+
+	entry, err := srv.CalendarList.Get(calendarId).Do()
+	if err != nil {
+		return nil, fmt.Errorf("unable to retrieve calendar list entry: %w", err)
+	}
+
+	return entry, nil
+}
@@ -0,0 +1,63 @@
+package utils
+
+import (
+	"sync"
+	"time"
+)
+
+// Limiter is a token bucket rate limiter, safe for concurrent use. A nil
+// Limiter does not limit.
+type Limiter struct {
+	mu     sync.Mutex
+	rate   float64 // tokens added per second
+	burst  float64
+	tokens float64
+	last   time.Time
+
+	now   func() time.Time
+	sleep func(time.Duration)
+}
+
+// NewLimiter allows rate events per second on average, with bursts of up to
+// burst events. It returns nil, meaning no limit, when rate is not positive.
+func NewLimiter(rate float64, burst int) *Limiter {
+	if rate <= 0 {
+		return nil
+	}
+	if burst < 1 {
+		burst = 1
+	}
+	return &Limiter{
+		rate:   rate,
+		burst:  float64(burst),
+		tokens: float64(burst),
+		now:    time.Now,
+		sleep:  time.Sleep,
+	}
+}
+
+// Wait blocks until an event is allowed.
+func (l *Limiter) Wait() {
+	if l == nil {
+		return
+	}
+
+	l.mu.Lock()
+	now := l.now()
+	if !l.last.IsZero() {
+		l.tokens = min(l.burst, l.tokens+now.Sub(l.last).Seconds()*l.rate)
+	}
+	l.last = now
+
+	// take the token now, and sleep until it would have been available
+	l.tokens--
+	var wait time.Duration
+	if l.tokens < 0 {
+		wait = time.Duration(-l.tokens / l.rate * float64(time.Second))
+	}
+	l.mu.Unlock()
+
+	if wait > 0 {
+		l.sleep(wait)
+	}
+}
@@ -0,0 +1,64 @@
+package utils
+
+import (
+	"testing"
+	"time"
+)
+
+// fakeClock advances only when the limiter sleeps.
+type fakeClock struct {
+	now   time.Time
+	slept time.Duration
+}
+
+func (c *fakeClock) sleep(d time.Duration) {
+	c.slept += d
+	c.now = c.now.Add(d)
+}
+
+func newTestLimiter(rate float64, burst int) (*Limiter, *fakeClock) {
+	clock := &fakeClock{now: time.Date(2026, 1, 31, 10, 0, 0, 0, time.UTC)}
+	l := NewLimiter(rate, burst)
+	l.now = func() time.Time { return clock.now }
+	l.sleep = clock.sleep
+	return l, clock
+}
+
+func TestLimiterBurstThenRate(t *testing.T) {
+	l, clock := newTestLimiter(10, 5)
+
+	for range 5 {
+		l.Wait()
+	}
+	if clock.slept != 0 {
+		t.Errorf("Expected the burst to pass without waiting, slept %v", clock.slept)
+	}
+
+	for range 10 {
+		l.Wait()
+	}
+	if clock.slept < 990*time.Millisecond || clock.slept > 1010*time.Millisecond {
+		t.Errorf("Expected 10 more events at 10/s to take about 1s, slept %v", clock.slept)
+	}
+}
+
+func TestLimiterRefills(t *testing.T) {
+	l, clock := newTestLimiter(2, 2)
+
+	l.Wait()
+	l.Wait()
+	clock.now = clock.now.Add(time.Second) // idle time refills the bucket
+	l.Wait()
+	l.Wait()
+	if clock.slept != 0 {
+		t.Errorf("Expected no waiting after a refill, slept %v", clock.slept)
+	}
+}
+
+func TestNilLimiter(t *testing.T) {
+	l := NewLimiter(0, 10)
+	if l != nil {
+		t.Fatal("Expected no limiter for a zero rate")
+	}
+	l.Wait() // must not panic
+}
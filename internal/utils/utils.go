@@ -2,8 +2,49 @@ package utils
 
 import (
 	"time"
+	_ "time/tzdata" // so LoadLocation works without system zoneinfo
 )
 
-func GetNowLocalAdjusted() time.Time {
-	return time.Now().Add(time.Hour * 7) // hardcoded for Asia/Bangkok
+// Location is the time zone events are displayed in. It defaults to the
+// system local zone (which honors TZ) and is set from the config at startup.
+var Location = time.Local
+
+// ExtraLocations are additional time zones shown next to Location in the
+// day and week grids.
+var ExtraLocations []*time.Location
+
+// LoadLocation resolves a time zone name such as "Asia/Bangkok". An empty
+// name means the system local zone.
+func LoadLocation(name string) (*time.Location, error) {
+	if name == "" {
+		return time.Local, nil
+	}
+	return time.LoadLocation(name)
+}
+
+// Now returns the current time in Location.
+func Now() time.Time {
+	return time.Now().In(Location)
+}
+
+// StartOfDay returns midnight of t's date in Location.
+func StartOfDay(t time.Time) time.Time {
+	return StartOfDayIn(t, Location)
+}
+
+// StartOfDayIn returns midnight of t's date in loc.
+func StartOfDayIn(t time.Time, loc *time.Location) time.Time {
+	return AtTimeIn(t, 0, 0, loc)
+}
+
+// AtTime returns the given wall-clock time on day's date in Location. Unlike
+// adding a duration to midnight, this stays correct across DST transitions.
+func AtTime(day time.Time, hour, minute int) time.Time {
+	return AtTimeIn(day, hour, minute, Location)
+}
+
+// AtTimeIn returns the given wall-clock time on day's date in loc.
+func AtTimeIn(day time.Time, hour, minute int, loc *time.Location) time.Time {
+	year, month, d := day.In(loc).Date()
+	return time.Date(year, month, d, hour, minute, 0, 0, loc)
 }
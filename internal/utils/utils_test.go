@@ -5,30 +5,79 @@ import (
 	"time"
 )
 
-func TestGetNowLocalAdjusted(t *testing.T) {
-	// Get the adjusted time
-	adjusted := GetNowLocalAdjusted()
+func setLocation(t *testing.T, name string) *time.Location {
+	t.Helper()
+	loc, err := LoadLocation(name)
+	if err != nil {
+		t.Fatalf("Failed to load location %q: %v", name, err)
+	}
+	previous := Location
+	Location = loc
+	t.Cleanup(func() { Location = previous })
+	return loc
+}
+
+func TestLoadLocation(t *testing.T) {
+	t.Run("empty name is system local", func(t *testing.T) {
+		loc, err := LoadLocation("")
+		if err != nil {
+			t.Fatalf("Unexpected error: %v", err)
+		}
+		if loc != time.Local {
+			t.Errorf("Expected time.Local, got %v", loc)
+		}
+	})
+
+	t.Run("named zone", func(t *testing.T) {
+		loc, err := LoadLocation("Asia/Bangkok")
+		if err != nil {
+			t.Fatalf("Unexpected error: %v", err)
+		}
+		if loc.String() != "Asia/Bangkok" {
+			t.Errorf("Expected Asia/Bangkok, got %v", loc)
+		}
+	})
+
+	t.Run("unknown zone returns error", func(t *testing.T) {
+		if _, err := LoadLocation("Mars/Olympus_Mons"); err == nil {
+			t.Error("Expected error for unknown zone, got nil")
+		}
+	})
+}
 
-	// Get the actual current time
-	now := time.Now()
+func TestNow(t *testing.T) {
+	loc := setLocation(t, "Europe/Berlin")
 
-	// The adjusted time should be approximately 7 hours ahead
-	expectedDiff := time.Hour * 7
-	actualDiff := adjusted.Sub(now)
+	now := Now()
+	if now.Location() != loc {
+		t.Errorf("Expected Now in %v, got %v", loc, now.Location())
+	}
+	if diff := time.Since(now); diff < 0 || diff > time.Second {
+		t.Errorf("Expected Now to be the current instant, off by %v", diff)
+	}
+}
 
-	// Allow for a small margin of error (1 second) due to execution time
-	margin := time.Second
+func TestStartOfDay(t *testing.T) {
+	setLocation(t, "Asia/Bangkok")
 
-	if actualDiff < expectedDiff-margin || actualDiff > expectedDiff+margin {
-		t.Errorf("Expected time difference of %v, got %v", expectedDiff, actualDiff)
+	// 20:00 UTC is already the next day in Bangkok
+	result := StartOfDay(time.Date(2026, 1, 31, 20, 0, 0, 0, time.UTC))
+	expected := time.Date(2026, 2, 1, 0, 0, 0, 0, Location)
+	if !result.Equal(expected) {
+		t.Errorf("StartOfDay() = %v, want %v", result, expected)
 	}
 }
 
-func TestGetNowLocalAdjustedReturnsTime(t *testing.T) {
-	// Simply verify the function returns a valid time.Time object
-	result := GetNowLocalAdjusted()
+func TestAtTimeAcrossDST(t *testing.T) {
+	setLocation(t, "Europe/Berlin")
 
-	if result.IsZero() {
-		t.Error("Expected non-zero time, got zero time")
+	// clocks jump from 02:00 to 03:00 on 2026-03-29
+	day := StartOfDay(time.Date(2026, 3, 29, 12, 0, 0, 0, Location))
+	result := AtTime(day, 9, 30)
+	if result.Hour() != 9 || result.Minute() != 30 {
+		t.Errorf("AtTime() = %v, want 09:30 local", result)
+	}
+	if naive := day.Add(9*time.Hour + 30*time.Minute); naive.Hour() == 9 {
+		t.Errorf("Expected adding a duration to midnight to be off by an hour, got %v", naive)
 	}
 }